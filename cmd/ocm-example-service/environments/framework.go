@@ -4,14 +4,20 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/cache"
 	"github.com/openshift-online/rh-trex/pkg/client/ocm"
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/db"
 	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
 )
 
 func init() {
@@ -22,6 +28,9 @@ func init() {
 		environment.Config = config.NewApplicationConfig()
 		environment.ApplicationConfig = ApplicationConfig{config.NewApplicationConfig()}
 		environment.Name = GetEnvironmentStrFromEnv()
+		environment.Clock = clock.New()
+		environment.Admission = admission.NewChain()
+		environment.Hooks = services.NewHooks()
 
 		environments = map[string]EnvironmentImpl{
 			DevelopmentEnv: &devEnvImpl{environment},
@@ -91,6 +100,12 @@ func (e *Env) Initialize() error {
 	if err := envImpl.VisitDatabase(&e.Database); err != nil {
 		glog.Fatalf("Failed to visit Database: %s", err)
 	}
+	e.SetClock(e.Clock)
+	e.LoadCaches()
+
+	if err := e.LoadAdmission(); err != nil {
+		return err
+	}
 
 	err := e.LoadClients()
 	if err != nil {
@@ -104,6 +119,7 @@ func (e *Env) Initialize() error {
 	if err := envImpl.VisitServices(&e.Services); err != nil {
 		glog.Fatalf("Failed to visit Services: %s", err)
 	}
+	e.RegisterHooks()
 
 	err = e.InitializeSentry()
 	if err != nil {
@@ -124,6 +140,45 @@ func (e *Env) Initialize() error {
 	return nil
 }
 
+// Isolated returns a copy of the initialized environment whose services use the given session factory.
+// The server configuration is copied too, so it can be changed without affecting e; clients and
+// handlers are shared. Integration tests use it to give each test a database and API server of its own.
+func (e *Env) Isolated(sessionFactory db.SessionFactory) *Env {
+	appConfig := *e.Config
+	serverConfig := *e.Config.Server
+	appConfig.Server = &serverConfig
+
+	isolated := &Env{
+		Name:              e.Name,
+		Handlers:          e.Handlers,
+		Clients:           e.Clients,
+		Database:          Database{SessionFactory: sessionFactory},
+		ApplicationConfig: ApplicationConfig{&appConfig},
+		Config:            &appConfig,
+		Admission:         e.Admission,
+		Hooks:             services.NewHooks(),
+	}
+	isolated.SetClock(e.Clock)
+	// the isolated services cache the objects of their own database
+	isolated.LoadCaches()
+	isolated.LoadServices()
+	if err := environments[e.Name].VisitServices(&isolated.Services); err != nil {
+		glog.Fatalf("Failed to visit Services: %s", err)
+	}
+	// the hooks are registered again, to use the isolated services
+	isolated.RegisterHooks()
+	return isolated
+}
+
+// SetClock makes the environment's services, controllers and database sessions take the time from c.
+// Tests set a clock.Fake to control time-based behavior.
+func (e *Env) SetClock(c clock.Clock) {
+	e.Clock = c
+	if e.Database.SessionFactory != nil {
+		e.Database.SessionFactory.SetClock(c)
+	}
+}
+
 func (e *Env) Seed() *errors.ServiceError {
 	return nil
 }
@@ -132,6 +187,15 @@ func (e *Env) LoadServices() {
 	e.Services.Generic = NewGenericServiceLocator(e)
 	e.Services.Dinosaurs = NewDinosaurServiceLocator(e)
 	e.Services.Events = NewEventServiceLocator(e)
+	e.Services.CustomKinds = NewCustomKindServiceLocator(e)
+	e.Services.CustomResources = NewCustomResourceServiceLocator(e)
+	// +trex:scaffold:locators
+}
+
+// LoadCaches creates the read caches of the kinds of the cache configuration, invalidated through the
+// notifications of the database. They expire with the environment's clock, so it must be set first.
+func (e *Env) LoadCaches() {
+	e.Caches = cache.NewRegistry(e.Config.Cache, e.Database.SessionFactory, e.Clock)
 }
 
 func (e *Env) LoadClients() error {
@@ -161,6 +225,52 @@ func (e *Env) LoadClients() error {
 	return nil
 }
 
+// LoadAdmission registers the admission hooks of the admission configuration with the chain the services
+// call, webhooks by their url and in-process hooks by the name they were registered under
+func (e *Env) LoadAdmission() error {
+	var registrations []admission.Registration
+	for _, hook := range e.Config.Admission.Hooks {
+		registration := admission.Registration{
+			Name:          hook.Name,
+			Kinds:         hook.Kinds,
+			Timeout:       time.Duration(hook.TimeoutSeconds) * time.Second,
+			FailurePolicy: admission.FailurePolicy(hook.FailurePolicy),
+		}
+		switch {
+		case hook.URL != "" && hook.Hook != "":
+			return fmt.Errorf("Admission hook %s has both a url and a hook", hook.Name)
+		case hook.URL != "":
+			registration.Hook = admission.NewWebhook(hook.URL, nil)
+		case hook.Hook != "":
+			found, ok := admission.LookupHook(hook.Hook)
+			if !ok {
+				return fmt.Errorf("Admission hook %s refers to hook %s, which isn't registered", hook.Name, hook.Hook)
+			}
+			registration.Hook = found
+		default:
+			return fmt.Errorf("Admission hook %s has neither a url nor a hook", hook.Name)
+		}
+		if registration.FailurePolicy == "" {
+			registration.FailurePolicy = admission.Fail
+		}
+		if registration.FailurePolicy != admission.Fail && registration.FailurePolicy != admission.Ignore {
+			return fmt.Errorf("Admission hook %s has an unknown failure policy %s", hook.Name, hook.FailurePolicy)
+		}
+		for _, operation := range hook.Operations {
+			switch op := admission.Operation(strings.ToUpper(operation)); op {
+			case admission.Create, admission.Update, admission.Delete:
+				registration.Operations = append(registration.Operations, op)
+			default:
+				return fmt.Errorf("Admission hook %s has an unknown operation %s", hook.Name, operation)
+			}
+		}
+		glog.Infof("Registering admission hook %s", hook.Name)
+		registrations = append(registrations, registration)
+	}
+	e.Admission = admission.NewChain(registrations...)
+	return nil
+}
+
 func (e *Env) InitializeSentry() error {
 	options := sentry.ClientOptions{}
 
@@ -1,11 +1,19 @@
 package environments
 
 import (
+	"context"
 	"os/exec"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db/db_session"
 )
 
 func BenchmarkGetDynos(b *testing.B) {
@@ -46,3 +54,60 @@ func TestLoadServices(t *testing.T) {
 		}
 	}
 }
+
+func TestIsolated(t *testing.T) {
+	env := &Env{Name: TestingEnv, Config: config.NewApplicationConfig()}
+	dbFactory := db_session.NewMemoryFactory(env.Config.Database)
+	defer dbFactory.Close()
+
+	isolated := env.Isolated(dbFactory)
+	if isolated.Database.SessionFactory != dbFactory {
+		t.Errorf("Isolated environment doesn't use its session factory")
+	}
+
+	isolated.Config.Server.BindAddress = "localhost:0"
+	if env.Config.Server.BindAddress == "localhost:0" {
+		t.Errorf("Isolated environment shares its server config")
+	}
+
+	s := reflect.ValueOf(isolated.Services)
+	for i := 0; i < s.NumField(); i++ {
+		if s.Field(i).IsNil() {
+			t.Errorf("Service %v is nil", s.Type().Field(i).Name)
+		}
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	env := &Env{Name: TestingEnv, Config: config.NewApplicationConfig(), Clock: clock.New()}
+	dbFactory := db_session.NewMemoryFactory(env.Config.Database)
+	defer dbFactory.Close()
+
+	isolated := env.Isolated(dbFactory)
+	if isolated.Clock != env.Clock {
+		t.Errorf("Isolated environment doesn't share the clock")
+	}
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(start)
+	isolated.SetClock(fake)
+
+	dinosaurDao := dao.NewDinosaurDao(&isolated.Database.SessionFactory)
+	dino, err := dinosaurDao.Create(context.Background(), &api.Dinosaur{Species: "Stegosaurus"})
+	if err != nil {
+		t.Fatalf("Unable to create dinosaur: %s", err)
+	}
+	if !dino.CreatedAt.Equal(start) {
+		t.Errorf("Dinosaur created at %s, not at the time of the clock %s", dino.CreatedAt, start)
+	}
+
+	fake.Advance(time.Hour)
+	dino.Species = "Triceratops"
+	dino, err = dinosaurDao.Replace(context.Background(), dino)
+	if err != nil {
+		t.Fatalf("Unable to replace dinosaur: %s", err)
+	}
+	if !dino.UpdatedAt.Equal(start.Add(time.Hour)) {
+		t.Errorf("Dinosaur updated at %s, not at the time of the clock %s", dino.UpdatedAt, start.Add(time.Hour))
+	}
+}
@@ -0,0 +1,13 @@
+package environments
+
+// RegisterHooks registers the lifecycle hooks of the services with e.Hooks, once the services are loaded so
+// the hooks can use them, e.g.
+//
+//	services.AfterCreate(e.Hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+//		_, err := e.Services.Eggs().Create(ctx, &api.Egg{DinosaurID: dinosaur.ID})
+//		return err
+//	})
+//
+// The hooks run in the transaction of the write, and a hook returning an error aborts it.
+func (e *Env) RegisterHooks() {
+}
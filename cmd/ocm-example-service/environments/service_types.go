@@ -1,6 +1,8 @@
 package environments
 
 import (
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/cache"
 	"github.com/openshift-online/rh-trex/pkg/dao"
 	"github.com/openshift-online/rh-trex/pkg/db"
 	"github.com/openshift-online/rh-trex/pkg/services"
@@ -11,9 +13,13 @@ type DinosaurServiceLocator func() services.DinosaurService
 func NewDinosaurServiceLocator(env *Env) DinosaurServiceLocator {
 	return func() services.DinosaurService {
 		return services.NewDinosaurService(
-			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory, env.Clock),
+			&env.Database.SessionFactory,
 			dao.NewDinosaurDao(&env.Database.SessionFactory),
 			env.Services.Events(),
+			env.Admission,
+			env.Hooks,
+			cache.For[api.Dinosaur](env.Caches, "Dinosaur"),
 		)
 	}
 }
@@ -33,3 +39,34 @@ func NewEventServiceLocator(env *Env) EventServiceLocator {
 		return services.NewEventService(dao.NewEventDao(&env.Database.SessionFactory))
 	}
 }
+
+type CustomKindServiceLocator func() services.CustomKindService
+
+func NewCustomKindServiceLocator(env *Env) CustomKindServiceLocator {
+	return func() services.CustomKindService {
+		return services.NewCustomKindService(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory, env.Clock),
+			dao.NewCustomKindDao(&env.Database.SessionFactory),
+			dao.NewCustomResourceDao(&env.Database.SessionFactory),
+		)
+	}
+}
+
+type CustomResourceServiceLocator func() services.CustomResourceService
+
+func NewCustomResourceServiceLocator(env *Env) CustomResourceServiceLocator {
+	return func() services.CustomResourceService {
+		return services.NewCustomResourceService(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory, env.Clock),
+			&env.Database.SessionFactory,
+			dao.NewCustomKindDao(&env.Database.SessionFactory),
+			dao.NewCustomResourceDao(&env.Database.SessionFactory),
+			env.Services.Generic(),
+			env.Services.Events(),
+			env.Admission,
+			env.Hooks,
+		)
+	}
+}
+
+// +trex:scaffold:service-locators
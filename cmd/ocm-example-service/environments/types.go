@@ -3,10 +3,14 @@ package environments
 import (
 	"sync"
 
+	"github.com/openshift-online/rh-trex/pkg/admission"
 	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/cache"
 	"github.com/openshift-online/rh-trex/pkg/client/ocm"
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/services"
 )
 
 const (
@@ -28,6 +32,14 @@ type Env struct {
 	ApplicationConfig ApplicationConfig
 	// most code relies on env.Config
 	Config *config.ApplicationConfig
+	// Clock is what services, controllers and the database sessions take the time from, see SetClock
+	Clock clock.Clock
+	// Admission is the chain of admission hooks the services call before their writes, see LoadAdmission
+	Admission *admission.Chain
+	// Hooks are the lifecycle hooks of the services, see RegisterHooks
+	Hooks *services.Hooks
+	// Caches are the read caches of the services, see LoadCaches
+	Caches *cache.Registry
 }
 
 type ApplicationConfig struct {
@@ -46,6 +58,10 @@ type Services struct {
 	Dinosaurs DinosaurServiceLocator
 	Generic   GenericServiceLocator
 	Events    EventServiceLocator
+	// the services of the kinds defined at runtime, see CustomKinds
+	CustomKinds     CustomKindServiceLocator
+	CustomResources CustomResourceServiceLocator
+	// +trex:scaffold:services
 }
 
 type Clients struct {
@@ -1,6 +1,8 @@
 package servecmd
 
 import (
+	"context"
+
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 
@@ -35,6 +37,11 @@ func runServe(cmd *cobra.Command, args []string) {
 		apiserver.Start()
 	}()
 
+	go func() {
+		grpcServer := server.NewGRPCServer()
+		grpcServer.Start()
+	}()
+
 	go func() {
 		metricsServer := server.NewMetricsServer()
 		metricsServer.Start()
@@ -50,5 +57,14 @@ func runServe(cmd *cobra.Command, args []string) {
 		controllersServer.Start()
 	}()
 
+	// the caches of every replica are invalidated by the writes of any
+	go environments.Environment().Caches.Listen(context.Background())
+
+	outboxServer, err := server.NewOutboxServer()
+	if err != nil {
+		glog.Fatalf("Unable to create the outbox relays: %s", err.Error())
+	}
+	go outboxServer.Start()
+
 	select {}
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"regexp"
 	"time"
 
 	_ "github.com/auth0/go-jwt-middleware"
@@ -13,16 +14,19 @@ import (
 	_ "github.com/golang-jwt/jwt/v4"
 	"github.com/golang/glog"
 	gorillahandlers "github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/openshift-online/ocm-sdk-go/authentication"
 
 	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/environments"
 	"github.com/openshift-online/rh-trex/data/generated/openapi"
 	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 type apiServer struct {
 	httpServer *http.Server
+	env        *environments.Env
 }
 
 var _ Server = &apiServer{}
@@ -32,7 +36,13 @@ func env() *environments.Env {
 }
 
 func NewAPIServer() Server {
-	s := &apiServer{}
+	return NewAPIServerForEnv(env())
+}
+
+// NewAPIServerForEnv creates an API server serving the services and configuration of e, rather than
+// those of the process-wide environment. The middleware wraps the server's handler, outermost last.
+func NewAPIServerForEnv(e *environments.Env, middleware ...mux.MiddlewareFunc) Server {
+	s := &apiServer{env: e}
 
 	mainRouter := s.routes()
 
@@ -40,11 +50,11 @@ func NewAPIServer() Server {
 	// 1) Attaches an instance of *sentry.Hub to the request’s context. Accessit by using the sentry.GetHubFromContext() method on the request
 	//   NOTE this is the only way middleware, handlers, and services should be reporting to sentry, through the hub
 	// 2) Reports panics to the configured sentry service
-	if env().Config.Sentry.Enabled {
+	if s.env.Config.Sentry.Enabled {
 		sentryhttpOptions := sentryhttp.Options{
 			Repanic:         true,
 			WaitForDelivery: false,
-			Timeout:         env().Config.Sentry.Timeout,
+			Timeout:         s.env.Config.Sentry.Timeout,
 		}
 		sentryMW := sentryhttp.New(sentryhttpOptions)
 		mainRouter.Use(sentryMW.Handle)
@@ -53,26 +63,9 @@ func NewAPIServer() Server {
 	// referring to the router as type http.Handler allows us to add middleware via more handlers
 	var mainHandler http.Handler = mainRouter
 
-	if env().Config.Server.EnableJWT {
-		// Create the logger for the authentication handler:
-		authnLogger, err := sdk.NewGlogLoggerBuilder().
-			InfoV(glog.Level(1)).
-			DebugV(glog.Level(5)).
-			Build()
-		check(err, "Unable to create authentication logger")
-
-		// Create the handler that verifies that tokens are valid:
-		mainHandler, err = authentication.NewHandler().
-			Logger(authnLogger).
-			KeysFile(env().Config.Server.JwkCertFile).
-			KeysURL(env().Config.Server.JwkCertURL).
-			ACLFile(env().Config.Server.ACLFile).
-			Public("^/api/ocm-example-service/?$").
-			Public("^/api/ocm-example-service/v1/?$").
-			Public("^/api/ocm-example-service/v1/openapi/?$").
-			Public("^/api/ocm-example-service/v1/errors(/.*)?$").
-			Next(mainHandler).
-			Build()
+	if s.env.Config.Server.EnableJWT {
+		var err error
+		mainHandler, err = newAuthenticationHandler(s.env, mainHandler)
 		check(err, "Unable to create authentication handler")
 	}
 
@@ -117,21 +110,54 @@ func NewAPIServer() Server {
 
 	mainHandler = removeTrailingSlash(mainHandler)
 
+	for _, mw := range middleware {
+		mainHandler = mw(mainHandler)
+	}
+
 	s.httpServer = &http.Server{
-		Addr:    env().Config.Server.BindAddress,
+		Addr:    s.env.Config.Server.BindAddress,
 		Handler: mainHandler,
 	}
 
 	return s
 }
 
+// newAuthenticationHandler creates the handler that verifies that the tokens of the requests to next are
+// valid, for the API and gRPC servers
+func newAuthenticationHandler(e *environments.Env, next http.Handler) (http.Handler, error) {
+	// Create the logger for the authentication handler:
+	authnLogger, err := sdk.NewGlogLoggerBuilder().
+		InfoV(glog.Level(1)).
+		DebugV(glog.Level(5)).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create authentication logger: %s", err)
+	}
+
+	handler, err := authentication.NewHandler().
+		Logger(authnLogger).
+		KeysFile(e.Config.Server.JwkCertFile).
+		KeysURL(e.Config.Server.JwkCertURL).
+		ACLFile(e.Config.Server.ACLFile).
+		Public("^" + regexp.QuoteMeta(identity.APIPrefix) + "/?$").
+		Public("^" + regexp.QuoteMeta(identity.BasePath) + "/?$").
+		Public("^" + regexp.QuoteMeta(identity.BasePath) + "/openapi/?$").
+		Public("^" + regexp.QuoteMeta(identity.ErrorsPath) + "(/.*)?$").
+		Next(next).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return handler, nil
+}
+
 // Serve start the blocking call to Serve.
 // Useful for breaking up ListenAndServer (Start) when you require the server to be listening before continuing
 func (s apiServer) Serve(listener net.Listener) {
 	var err error
-	if env().Config.Server.EnableHTTPS {
+	if s.env.Config.Server.EnableHTTPS {
 		// Check https cert and key path path
-		if env().Config.Server.HTTPSCertFile == "" || env().Config.Server.HTTPSKeyFile == "" {
+		if s.env.Config.Server.HTTPSCertFile == "" || s.env.Config.Server.HTTPSKeyFile == "" {
 			check(
 				fmt.Errorf("Unspecified required --https-cert-file, --https-key-file"),
 				"Can't start https server",
@@ -139,10 +165,10 @@ func (s apiServer) Serve(listener net.Listener) {
 		}
 
 		// Serve with TLS
-		glog.Infof("Serving with TLS at %s", env().Config.Server.BindAddress)
-		err = s.httpServer.ServeTLS(listener, env().Config.Server.HTTPSCertFile, env().Config.Server.HTTPSKeyFile)
+		glog.Infof("Serving with TLS at %s", s.env.Config.Server.BindAddress)
+		err = s.httpServer.ServeTLS(listener, s.env.Config.Server.HTTPSCertFile, s.env.Config.Server.HTTPSKeyFile)
 	} else {
-		glog.Infof("Serving without TLS at %s", env().Config.Server.BindAddress)
+		glog.Infof("Serving without TLS at %s", s.env.Config.Server.BindAddress)
 		err = s.httpServer.Serve(listener)
 	}
 
@@ -154,7 +180,7 @@ func (s apiServer) Serve(listener net.Listener) {
 // Listen only start the listener, not the server.
 // Useful for breaking up ListenAndServer (Start) when you require the server to be listening before continuing
 func (s apiServer) Listen() (listener net.Listener, err error) {
-	return net.Listen("tcp", env().Config.Server.BindAddress)
+	return net.Listen("tcp", s.env.Config.Server.BindAddress)
 }
 
 // Start listening on the configured port and start the server. This is a convenience wrapper for Listen() and Serve(listener Listener)
@@ -168,7 +194,7 @@ func (s apiServer) Start() {
 	// after the server exits but before the application terminates
 	// we need to explicitly close Go's sql connection pool.
 	// this needs to be called *exactly* once during an app's lifetime.
-	env().Database.SessionFactory.Close()
+	s.env.Database.SessionFactory.Close()
 }
 
 func (s apiServer) Stop() error {
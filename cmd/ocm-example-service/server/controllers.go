@@ -3,20 +3,29 @@ package server
 import (
 	"context"
 
+	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/environments"
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/controllers"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/services"
 
 	"github.com/openshift-online/rh-trex/pkg/logger"
 )
 
 func NewControllersServer() *ControllersServer {
+	return NewControllersServerForEnv(env())
+}
+
+// NewControllersServerForEnv creates a controllers server reconciling the events of e, rather than those
+// of the process-wide environment
+func NewControllersServerForEnv(e *environments.Env) *ControllersServer {
 
 	s := &ControllersServer{
-		KindControllerManager: controllers.NewKindControllerManager(env().Services.Events()),
+		KindControllerManager: controllers.NewKindControllerManager(e.Services.Events(), e.Clock),
+		env:                   e,
 	}
 
-	dinoServices := env().Services.Dinosaurs()
+	dinoServices := e.Services.Dinosaurs()
 
 	s.KindControllerManager.Add(&controllers.ControllerConfig{
 		Source: "Dinosaurs",
@@ -27,12 +36,27 @@ func NewControllersServer() *ControllersServer {
 		},
 	})
 
+	// +trex:scaffold:controllers
+
+	// the resources of every custom kind have the same controllers, which can tell them apart by kind
+	customResourceServices := e.Services.CustomResources()
+
+	s.KindControllerManager.Add(&controllers.ControllerConfig{
+		Source: services.CustomResourcesEventSource,
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.CreateEventType: {customResourceServices.OnUpsert},
+			api.UpdateEventType: {customResourceServices.OnUpsert},
+			api.DeleteEventType: {customResourceServices.OnDelete},
+		},
+	})
+
 	return s
 }
 
 type ControllersServer struct {
 	KindControllerManager *controllers.KindControllerManager
 	DB                    db.SessionFactory
+	env                   *environments.Env
 }
 
 // Start is a blocking call that starts this controller server
@@ -42,5 +66,5 @@ func (s ControllersServer) Start() {
 	log.Infof("Kind controller listening for events")
 
 	// blocking call
-	env().Database.SessionFactory.NewListener(context.Background(), "events", s.KindControllerManager.Handle)
+	s.env.Database.SessionFactory.NewListener(context.Background(), "events", s.KindControllerManager.Handle)
 }
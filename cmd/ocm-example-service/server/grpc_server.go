@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/golang/glog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/environments"
+	"github.com/openshift-online/rh-trex/pkg/api/pb"
+	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/rpc"
+)
+
+type grpcServer struct {
+	grpcServer *grpc.Server
+	watches    *rpc.Watches
+	// ctx ends the listener on the events channel when the server stops
+	ctx  context.Context
+	stop context.CancelFunc
+	env  *environments.Env
+}
+
+var _ Server = &grpcServer{}
+
+func NewGRPCServer() Server {
+	return NewGRPCServerForEnv(env())
+}
+
+// NewGRPCServerForEnv creates a gRPC server serving the services of e over gRPC, as the API server
+// serves them over REST. Calls are authenticated and authorized like the API server's requests, and unary
+// calls run in a transaction.
+func NewGRPCServerForEnv(e *environments.Env) Server {
+	ctx, stop := context.WithCancel(context.Background())
+	s := &grpcServer{
+		watches: rpc.NewWatches(e.Services.Events()),
+		ctx:     ctx,
+		stop:    stop,
+		env:     e,
+	}
+
+	unary := []grpc.UnaryServerInterceptor{rpc.OperationIDInterceptor, rpc.MetricsInterceptor}
+	stream := []grpc.StreamServerInterceptor{rpc.OperationIDStreamInterceptor, rpc.MetricsStreamInterceptor}
+	if e.Config.Server.EnableJWT {
+		authMiddleware, err := auth.NewAuthMiddleware()
+		check(err, "Unable to create auth middleware")
+		authenticator := rpc.NewAuthenticator(
+			func(next http.Handler) http.Handler {
+				handler, err := newAuthenticationHandler(e, next)
+				check(err, "Unable to create authentication handler")
+				return handler
+			},
+			authMiddleware.AuthenticateAccountJWT,
+		)
+		unary = append(unary, authenticator.Interceptor)
+		stream = append(stream, authenticator.StreamInterceptor)
+	}
+	// the same authorization as the routes of the API server
+	authorizer := rpc.NewAuthorizer(auth.NewAuthzMiddlewareMock())
+	unary = append(unary, authorizer.Interceptor)
+	stream = append(stream, authorizer.StreamInterceptor)
+	unary = append(unary, rpc.TransactionInterceptor(e.Database.SessionFactory))
+
+	options := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	if e.Config.Server.EnableHTTPS {
+		// Check https cert and key path path
+		if e.Config.Server.HTTPSCertFile == "" || e.Config.Server.HTTPSKeyFile == "" {
+			check(
+				fmt.Errorf("Unspecified required --https-cert-file, --https-key-file"),
+				"Can't start gRPC server with TLS",
+			)
+		}
+		creds, err := credentials.NewServerTLSFromFile(e.Config.Server.HTTPSCertFile, e.Config.Server.HTTPSKeyFile)
+		check(err, "Unable to load the TLS certificate of the gRPC server")
+		options = append(options, grpc.Creds(creds))
+	}
+	s.grpcServer = grpc.NewServer(options...)
+
+	pb.RegisterDinosaursServer(s.grpcServer, rpc.NewDinosaurServer(e.Services.Dinosaurs(), e.Services.Generic(), s.watches))
+	// +trex:scaffold:grpc
+
+	return s
+}
+
+// Serve starts the blocking call to Serve, and the listener on the events channel for the watches
+func (s *grpcServer) Serve(listener net.Listener) {
+	go s.env.Database.SessionFactory.NewListener(s.ctx, "events", s.watches.Handle)
+
+	glog.Infof("Serving gRPC at %s", listener.Addr())
+	err := s.grpcServer.Serve(listener)
+	check(err, "gRPC server terminated with errors")
+	glog.Info("gRPC server terminated")
+}
+
+// Listen only starts the listener, not the server
+func (s *grpcServer) Listen() (net.Listener, error) {
+	return net.Listen("tcp", s.env.Config.GRPC.BindAddress)
+}
+
+// Start listening on the configured port and start the server
+func (s *grpcServer) Start() {
+	if !s.env.Config.GRPC.Enabled {
+		glog.Info("gRPC server disabled")
+		return
+	}
+	listener, err := s.Listen()
+	if err != nil {
+		glog.Fatalf("Unable to start gRPC server: %s", err)
+	}
+	s.Serve(listener)
+}
+
+// Stop ends the watches, which would otherwise keep the server from stopping, then stops the server
+// once the other calls finished
+func (s *grpcServer) Stop() error {
+	s.stop()
+	s.watches.Close()
+	s.grpcServer.GracefulStop()
+	return nil
+}
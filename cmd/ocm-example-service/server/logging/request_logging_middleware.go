@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 func RequestLoggingMiddleware(handler http.Handler) http.Handler {
@@ -13,7 +15,7 @@ func RequestLoggingMiddleware(handler http.Handler) http.Handler {
 
 		// these contribute greatly to log spam but are not useful or meaningful.
 		// consider a list/map of URLs should this grow in the future.
-		if path == "/api/ocm-example-service" {
+		if path == identity.APIPrefix {
 			doLog = false
 		}
 
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/environments"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/identity"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+	"github.com/openshift-online/rh-trex/pkg/outbox"
+)
+
+type OutboxServer struct {
+	Relays []*outbox.Relay
+}
+
+func NewOutboxServer() (*OutboxServer, error) {
+	return NewOutboxServerForEnv(env())
+}
+
+// NewOutboxServerForEnv creates a relay of the events of e to each of the publishers configured
+func NewOutboxServerForEnv(e *environments.Env) (*OutboxServer, error) {
+	config := e.Config.Outbox
+	s := &OutboxServer{}
+
+	for _, name := range config.Publishers {
+		var publisher outbox.Publisher
+		switch name {
+		case "kafka":
+			publisher = outbox.NewKafkaPublisher(config.KafkaBrokers, config.KafkaTopic, identity.ID, config.KafkaTimeout)
+		case "file":
+			filePublisher, err := outbox.NewFilePublisher(config.File)
+			if err != nil {
+				return nil, err
+			}
+			publisher = filePublisher
+		default:
+			return nil, fmt.Errorf("unknown outbox publisher '%s', expected kafka or file", name)
+		}
+
+		s.Relays = append(s.Relays, outbox.NewRelay(
+			publisher,
+			dao.NewOutboxDao(&e.Database.SessionFactory),
+			db.NewAdvisoryLockFactory(e.Database.SessionFactory, e.Clock),
+			e.Clock,
+			config,
+		))
+	}
+
+	return s, nil
+}
+
+// Start is a blocking call that relays the events to the publishers
+func (s *OutboxServer) Start() {
+	log := logger.NewOCMLogger(context.Background())
+
+	if len(s.Relays) == 0 {
+		log.Infof("No outbox publishers configured")
+		return
+	}
+	log.Infof("Outbox relaying events to %d publishers", len(s.Relays))
+
+	var wg sync.WaitGroup
+	for _, relay := range s.Relays {
+		wg.Add(1)
+		go func(relay *outbox.Relay) {
+			defer wg.Done()
+			relay.Run(context.Background())
+		}(relay)
+	}
+	wg.Wait()
+}
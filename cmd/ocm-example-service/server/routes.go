@@ -9,13 +9,16 @@ import (
 	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/server/logging"
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/cache"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/graphql"
 	"github.com/openshift-online/rh-trex/pkg/handlers"
+	"github.com/openshift-online/rh-trex/pkg/identity"
 	"github.com/openshift-online/rh-trex/pkg/logger"
 )
 
 func (s *apiServer) routes() *mux.Router {
-	services := &env().Services
+	services := &s.env.Services
 
 	openAPIDefinitions, err := s.loadOpenAPISpec("openapi.yaml")
 	if err != nil {
@@ -31,7 +34,7 @@ func (s *apiServer) routes() *mux.Router {
 	}
 
 	authzMiddleware := auth.NewAuthzMiddlewareMock()
-	if env().Config.Server.EnableJWT {
+	if s.env.Config.Server.EnableJWT {
 		// TODO: authzMiddleware, err = auth.NewAuthzMiddleware()
 		check(err, "Unable to create auth middleware")
 	}
@@ -46,18 +49,21 @@ func (s *apiServer) routes() *mux.Router {
 	// Request logging middleware logs pertinent information about the request and response
 	mainRouter.Use(logging.RequestLoggingMiddleware)
 
+	// Cache bypass middleware has the reads of the requests with an X-Cache-Bypass: true header skip the read caches
+	mainRouter.Use(cache.BypassMiddleware)
+
 	//  /api/ocm-example-service
-	apiRouter := mainRouter.PathPrefix("/api/ocm-example-service").Subrouter()
+	apiRouter := mainRouter.PathPrefix(identity.APIPrefix).Subrouter()
 	apiRouter.HandleFunc("", api.SendAPI).Methods(http.MethodGet)
 
 	//  /api/ocm-example-service/v1
-	apiV1Router := apiRouter.PathPrefix("/v1").Subrouter()
+	apiV1Router := apiRouter.PathPrefix("/" + identity.APIVersion).Subrouter()
 	apiV1Router.HandleFunc("", api.SendAPIV1).Methods(http.MethodGet)
 	apiV1Router.HandleFunc("/", api.SendAPIV1).Methods(http.MethodGet)
 
 	//  /api/ocm-example-service/v1/openapi
 	apiV1Router.HandleFunc("/openapi", handlers.NewOpenAPIHandler(openAPIDefinitions).Get).Methods(http.MethodGet)
-	registerApiMiddleware(apiV1Router)
+	s.registerApiMiddleware(apiV1Router)
 
 	//  /api/ocm-example-service/v1/errors
 	apiV1ErrorsRouter := apiV1Router.PathPrefix("/errors").Subrouter()
@@ -75,15 +81,51 @@ func (s *apiServer) routes() *mux.Router {
 
 	apiV1DinosaursRouter.Use(authzMiddleware.AuthorizeApi)
 
+	// +trex:scaffold:routes
+
+	//  /api/ocm-example-service/v1/custom_kinds
+	customKindHandler := handlers.NewCustomKindHandler(services.CustomKinds(), services.Generic())
+	customResourceHandler := handlers.NewCustomResourceHandler(services.CustomResources())
+	// only admins define and delete custom kinds, everyone may use their resources
+	adminMiddleware := auth.NewAdminMiddleware(s.env.Config.Server.AdminUsers)
+
+	apiV1CustomKindsRouter := apiV1Router.PathPrefix("/custom_kinds").Subrouter()
+	apiV1CustomKindsRouter.HandleFunc("", customKindHandler.List).Methods(http.MethodGet)
+	apiV1CustomKindsRouter.HandleFunc("/{name}", customKindHandler.Get).Methods(http.MethodGet)
+	apiV1CustomKindsRouter.Handle("", adminMiddleware.AuthorizeAdmin(http.HandlerFunc(customKindHandler.Create))).Methods(http.MethodPost)
+	apiV1CustomKindsRouter.Handle("/{name}", adminMiddleware.AuthorizeAdmin(http.HandlerFunc(customKindHandler.Delete))).Methods(http.MethodDelete)
+
+	//  /api/ocm-example-service/v1/custom_kinds/{name}/resources
+	apiV1CustomKindsRouter.HandleFunc("/{name}/resources", customResourceHandler.List).Methods(http.MethodGet)
+	apiV1CustomKindsRouter.HandleFunc("/{name}/resources/{id}", customResourceHandler.Get).Methods(http.MethodGet)
+	apiV1CustomKindsRouter.HandleFunc("/{name}/resources", customResourceHandler.Create).Methods(http.MethodPost)
+	apiV1CustomKindsRouter.HandleFunc("/{name}/resources/{id}", customResourceHandler.Patch).Methods(http.MethodPatch)
+	apiV1CustomKindsRouter.HandleFunc("/{name}/resources/{id}", customResourceHandler.Delete).Methods(http.MethodDelete)
+	apiV1CustomKindsRouter.Use(authMiddleware.AuthenticateAccountJWT)
+	apiV1CustomKindsRouter.Use(authzMiddleware.AuthorizeApi)
+
+	//  /api/ocm-example-service/v1/graphql
+	if s.env.Config.Server.EnableGraphQL {
+		schema, err := graphql.NewSchema(services.Generic(),
+			graphql.NewKind(services.Dinosaurs().FindByIDs),
+			// +trex:scaffold:graphql
+		)
+		check(err, "Unable to create the GraphQL schema")
+		apiV1GraphQLRouter := apiV1Router.PathPrefix("/graphql").Subrouter()
+		apiV1GraphQLRouter.HandleFunc("", handlers.NewGraphQLHandler(schema).Query).Methods(http.MethodGet, http.MethodPost)
+		apiV1GraphQLRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		apiV1GraphQLRouter.Use(authzMiddleware.AuthorizeApi)
+	}
+
 	return mainRouter
 }
 
-func registerApiMiddleware(router *mux.Router) {
+func (s *apiServer) registerApiMiddleware(router *mux.Router) {
 	router.Use(MetricsMiddleware)
 
 	router.Use(
 		func(next http.Handler) http.Handler {
-			return db.TransactionMiddleware(next, env().Database.SessionFactory)
+			return db.TransactionMiddleware(next, s.env.Database.SessionFactory)
 		},
 	)
 
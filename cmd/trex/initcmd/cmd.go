@@ -0,0 +1,136 @@
+package initcmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/manifest"
+)
+
+var (
+	manifestPath = manifest.DefaultPath
+	name         = ""
+	shortName    = ""
+	module       = ""
+	apiPrefix    = ""
+	errorPrefix  = ""
+	dbName       = ""
+	skipVerify   = false
+)
+
+// init sub-command renames a fresh copy of TRex into a new service
+func NewInitCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Rename this copy of TRex into a new service",
+		Long: `Rename this copy of TRex into a new service.
+
+Every name the service is known by, as recorded in the identity section of the manifest, is replaced
+in the contents and paths of all files tracked by git: the go module path, the binary and cmd
+directory name, the API base path, the error code prefix and the database name. The replacements are
+recorded in the manifest so that 'trex upgrade' can apply them to upstream changes. The renamed
+service is built and vetted afterwards.`,
+		Run: runInit,
+	}
+
+	cmd.Flags().StringVar(&manifestPath, "manifest", manifestPath, "path to the service's trex manifest, relative to the service root")
+	cmd.Flags().StringVar(&name, "name", name, "service name, used for the binary, cmd directory and deployments, e.g. rockets-service")
+	cmd.Flags().StringVar(&shortName, "short-name", shortName, "abbreviated name for openshift resources, defaults to --name")
+	cmd.Flags().StringVar(&module, "module", module, "go module path, e.g. github.com/org/rockets-service")
+	cmd.Flags().StringVar(&apiPrefix, "api-prefix", apiPrefix, "base path of the REST API, defaults to /api/<name>")
+	cmd.Flags().StringVar(&errorPrefix, "error-prefix", errorPrefix, "prefix of error codes, defaults to the upper-cased name")
+	cmd.Flags().StringVar(&dbName, "db-name", dbName, "database name, defaults to the name with underscores")
+	cmd.Flags().BoolVar(&skipVerify, "skip-verify", skipVerify, "don't build and vet the renamed service")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("module")
+	return cmd
+}
+
+func runInit(_ *cobra.Command, _ []string) {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		glog.Fatalf("Unable to load manifest: %s", err)
+	}
+	if m.Identity.Name == "" || m.Identity.Module == "" {
+		glog.Fatalf("%s has no identity to rename from", manifestPath)
+	}
+
+	to := manifest.Identity{
+		Name:        name,
+		ShortName:   shortName,
+		Module:      module,
+		APIPrefix:   apiPrefix,
+		ErrorPrefix: errorPrefix,
+		DBName:      dbName,
+	}
+	if to.ShortName == "" {
+		to.ShortName = to.Name
+	}
+	if to.APIPrefix == "" {
+		to.APIPrefix = "/api/" + to.Name
+	}
+	if to.ErrorPrefix == "" {
+		to.ErrorPrefix = strings.ToUpper(to.Name)
+	}
+	if to.DBName == "" {
+		to.DBName = strings.ReplaceAll(to.Name, "-", "_")
+	}
+	if !strings.HasPrefix(to.APIPrefix, "/") {
+		glog.Fatalf("--api-prefix must start with /, got %q", to.APIPrefix)
+	}
+
+	root := filepath.Dir(manifestPath)
+	// pin the upstream version before anything changes, trex upgrade merges from it
+	if m.Version == "" {
+		version, err := run(root, "git", "rev-parse", "HEAD")
+		if err != nil {
+			glog.Fatalf("Unable to find the upstream version: %s", err)
+		}
+		m.Version = strings.TrimSpace(version)
+	}
+
+	files, err := trackedFiles(root)
+	if err != nil {
+		glog.Fatalf("Unable to list files: %s", err)
+	}
+	manifestFile, _ := filepath.Rel(root, manifestPath)
+	var renameable []string
+	for _, path := range files {
+		// the manifest keeps the upstream names, as the source of its replacements
+		if path != filepath.ToSlash(manifestFile) {
+			renameable = append(renameable, path)
+		}
+	}
+
+	replacements := m.Identity.ReplacementsTo(to)
+	changed, err := Rename(root, renameable, replacements)
+	if err != nil {
+		glog.Fatalf("Unable to rename service: %s", err)
+	}
+	for _, path := range changed {
+		fmt.Printf("renamed %s\n", path)
+	}
+
+	from := m.Identity
+	m.Replacements = append(m.Replacements, replacements...)
+	m.Identity = to
+	if err := m.Save(manifestPath); err != nil {
+		glog.Fatalf("Unable to save manifest: %s", err)
+	}
+	fmt.Printf("renamed %s to %s: %d files changed\n", from.Name, to.Name, len(changed))
+
+	if skipVerify {
+		return
+	}
+	if _, err := run(root, "go", "build", "./cmd/...", "./pkg/...", "./test/..."); err != nil {
+		glog.Fatalf("The renamed service doesn't build: %s", err)
+	}
+	if _, err := run(root, "go", "vet", "./cmd/...", "./pkg/..."); err != nil {
+		glog.Fatalf("The renamed service doesn't vet: %s", err)
+	}
+	fmt.Println("the renamed service builds and vets")
+}
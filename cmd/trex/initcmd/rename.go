@@ -0,0 +1,114 @@
+package initcmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/manifest"
+)
+
+// Rename applies the replacements to the contents and paths of the files, which are relative to
+// root. It returns the paths, after renaming, of the files that changed.
+func Rename(root string, files []string, replacements []manifest.Replacement) ([]string, error) {
+	m := &manifest.Manifest{Replacements: replacements}
+
+	var changed []string
+	dirs := map[string]bool{}
+	for _, path := range files {
+		local := filepath.Join(root, path)
+		info, err := os.Lstat(local)
+		if os.IsNotExist(err) {
+			// deleted but not yet committed
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		contents, err := os.ReadFile(local)
+		if err != nil {
+			return nil, err
+		}
+		renamed := contents
+		if !isBinary(contents) {
+			renamed = []byte(m.Replace(string(contents)))
+		}
+		newPath := m.Replace(path)
+		if newPath == path && bytes.Equal(renamed, contents) {
+			continue
+		}
+		changed = append(changed, newPath)
+
+		newLocal := filepath.Join(root, newPath)
+		if err := os.MkdirAll(filepath.Dir(newLocal), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(newLocal, renamed, info.Mode().Perm()); err != nil {
+			return nil, err
+		}
+		if newPath != path {
+			if err := os.Remove(local); err != nil {
+				return nil, err
+			}
+			dirs[filepath.Dir(local)] = true
+		}
+	}
+
+	// drop the directories emptied by renames, deepest first so their parents can empty too
+	var emptied []string
+	for dir := range dirs {
+		for ; dir != root && dir != "." && dir != string(filepath.Separator); dir = filepath.Dir(dir) {
+			emptied = append(emptied, dir)
+		}
+	}
+	sort.Slice(emptied, func(i, j int) bool { return len(emptied[i]) > len(emptied[j]) })
+	for _, dir := range emptied {
+		if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+			if err := os.Remove(dir); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// trackedFiles lists the files git tracks under root
+func trackedFiles(root string) ([]string, error) {
+	out, err := run(root, "git", "ls-files")
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, path := range strings.Split(strings.TrimSpace(out), "\n") {
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+func run(dir, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s %s: %v: %s", name, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+func isBinary(contents []byte) bool {
+	return bytes.IndexByte(contents, 0) >= 0
+}
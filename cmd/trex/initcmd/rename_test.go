@@ -0,0 +1,45 @@
+package initcmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/manifest"
+)
+
+func TestRename(t *testing.T) {
+	RegisterTestingT(t)
+
+	root := t.TempDir()
+	files := map[string]string{
+		"cmd/fossil-service/main.go": "import \"github.com/org/fossils/pkg/api\"\n",
+		"pkg/api/error.go":           "const ERROR_CODE_PREFIX = \"FOSSIL\"\n",
+		"docs/unrelated.md":          "nothing to see here\n",
+	}
+	var paths []string
+	for path, contents := range files {
+		Expect(os.MkdirAll(filepath.Dir(filepath.Join(root, path)), 0755)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(root, path), []byte(contents), 0644)).To(Succeed())
+		paths = append(paths, path)
+	}
+
+	from := manifest.Identity{Name: "fossil-service", Module: "github.com/org/fossils", APIPrefix: "/api/fossil-service", ErrorPrefix: "FOSSIL", DBName: "fossils"}
+	to := manifest.Identity{Name: "rockets", Module: "github.com/org/rockets", APIPrefix: "/api/rockets", ErrorPrefix: "ROCKETS", DBName: "rockets"}
+	changed, err := Rename(root, paths, from.ReplacementsTo(to))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(changed).To(Equal([]string{"cmd/rockets/main.go", "pkg/api/error.go"}))
+
+	main, err := os.ReadFile(filepath.Join(root, "cmd/rockets/main.go"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(string(main)).To(Equal("import \"github.com/org/rockets/pkg/api\"\n"))
+	errors, err := os.ReadFile(filepath.Join(root, "pkg/api/error.go"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(string(errors)).To(Equal("const ERROR_CODE_PREFIX = \"ROCKETS\"\n"))
+
+	// the old cmd directory is gone
+	_, err = os.Stat(filepath.Join(root, "cmd/fossil-service"))
+	Expect(os.IsNotExist(err)).To(BeTrue())
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/initcmd"
+	"github.com/openshift-online/rh-trex/cmd/trex/upgrade"
+)
+
+func main() {
+	// This is needed to make `glog` believe that the flags have already been parsed, otherwise
+	// every log messages is prefixed by an error message stating the the flags haven't been
+	// parsed.
+	_ = flag.CommandLine.Parse([]string{})
+
+	// Always log to stderr by default
+	if err := flag.Set("logtostderr", "true"); err != nil {
+		glog.Infof("Unable to set logtostderr to true")
+	}
+
+	rootCmd := &cobra.Command{
+		Use:  "trex",
+		Long: "trex manages services built from the TRex framework",
+	}
+
+	// All subcommands under root
+	initCmd := initcmd.NewInitCommand()
+	upgradeCmd := upgrade.NewUpgradeCommand()
+
+	// Add subcommand(s)
+	rootCmd.AddCommand(initCmd, upgradeCmd)
+
+	if err := rootCmd.Execute(); err != nil {
+		glog.Fatalf("error running command: %v", err)
+	}
+}
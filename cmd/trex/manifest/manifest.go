@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// DefaultPath is where a TRex service keeps its manifest, relative to the repository root
+const DefaultPath = "trex.yaml"
+
+// Manifest tells the trex tools which files of a TRex-derived service are framework, shared with
+// upstream TRex, and which are the service's own business logic.
+type Manifest struct {
+	// Upstream is the TRex git repository the service was created from
+	Upstream string `json:"upstream"`
+	// Version is the upstream commit the framework files were last synced with
+	Version string `json:"version,omitempty"`
+	// Framework globs select the files `trex upgrade` keeps in sync with upstream
+	Framework []string `json:"framework"`
+	// Business globs select files that are never synced, even if they also match Framework
+	Business []string `json:"business,omitempty"`
+	// Replacements are applied, in order, to upstream paths and contents so they match a service
+	// that was renamed by `trex init`
+	Replacements []Replacement `json:"replacements,omitempty"`
+	// Identity is how the service currently names itself, `trex init` replaces it
+	Identity Identity `json:"identity"`
+}
+
+// Identity holds the names a TRex service is known by, in code, urls, errors and infrastructure
+type Identity struct {
+	// Name is the binary, cmd directory and deployment name, e.g. ocm-example-service
+	Name string `json:"name"`
+	// ShortName abbreviates Name in openshift resource names, e.g. ocm-ex-service
+	ShortName string `json:"shortName,omitempty"`
+	// Module is the go module path
+	Module string `json:"module"`
+	// APIPrefix is the base path of the REST API, e.g. /api/ocm-example-service
+	APIPrefix string `json:"apiPrefix"`
+	// ErrorPrefix starts every error code, e.g. OCM-EXAMPLE
+	ErrorPrefix string `json:"errorPrefix"`
+	// DBName is the database name
+	DBName string `json:"dbName"`
+}
+
+type Replacement struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ReplacementsTo lists the rewrites that rename a service from identity i to identity to. Besides
+// the fields themselves these cover the forms derived from them, such as the openapi client's
+// ApiOcmExampleServiceV1 method names and the ocm_example_service database user.
+func (i Identity) ReplacementsTo(to Identity) []Replacement {
+	var replacements []Replacement
+	add := func(from, to string) {
+		if from == "" || from == to {
+			return
+		}
+		for _, r := range replacements {
+			if r.From == from {
+				return
+			}
+		}
+		replacements = append(replacements, Replacement{From: from, To: to})
+	}
+
+	add(i.Module, to.Module)
+	add(i.APIPrefix, to.APIPrefix)
+	// json pointers in the openapi spec escape / as ~1
+	add(strings.ReplaceAll(i.APIPrefix, "/", "~1"), strings.ReplaceAll(to.APIPrefix, "/", "~1"))
+	add(camel(i.APIPrefix), camel(to.APIPrefix))
+	add(i.Name, to.Name)
+	add(snake(i.Name), snake(to.Name))
+	add(flat(i.Name), flat(to.Name))
+	add(i.ShortName, to.ShortName)
+	add(i.ErrorPrefix, to.ErrorPrefix)
+	// the API metadata ID
+	add(snake(i.ErrorPrefix), snake(to.ErrorPrefix))
+	add(i.DBName, to.DBName)
+
+	// longest first, so a name is never partly rewritten by a replacement of its prefix
+	sort.SliceStable(replacements, func(a, b int) bool {
+		return len(replacements[a].From) > len(replacements[b].From)
+	})
+	return replacements
+}
+
+var wordSeparators = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// camel turns /api/ocm-example-service into ApiOcmExampleService, as openapi-generator does
+func camel(s string) string {
+	var b strings.Builder
+	for _, word := range wordSeparators.Split(s, -1) {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+func snake(s string) string {
+	return strings.Trim(wordSeparators.ReplaceAllString(strings.ToLower(s), "_"), "_")
+}
+
+func flat(s string) string {
+	return wordSeparators.ReplaceAllString(strings.ToLower(s), "")
+}
+
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := yaml.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (m *Manifest) Save(path string) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// IsFramework reports whether the upstream file at path is synced by `trex upgrade`
+func (m *Manifest) IsFramework(path string) bool {
+	for _, glob := range m.Business {
+		if Match(glob, path) {
+			return false
+		}
+	}
+	for _, glob := range m.Framework {
+		if Match(glob, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Replace applies the manifest's replacements to s
+func (m *Manifest) Replace(s string) string {
+	for _, r := range m.Replacements {
+		s = strings.ReplaceAll(s, r.From, r.To)
+	}
+	return s
+}
+
+// Match reports whether a slash separated path matches glob. `*` matches within a path segment,
+// `**` matches across segments and `?` matches a single character.
+func Match(glob, path string) bool {
+	var re strings.Builder
+	re.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				// "**/" also matches no directories at all
+				if i+1 < len(glob) && glob[i+1] == '/' {
+					i++
+					re.WriteString("(.*/)?")
+				} else {
+					re.WriteString(".*")
+				}
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	re.WriteString("$")
+	matched, err := regexp.MatchString(re.String(), path)
+	return err == nil && matched
+}
@@ -0,0 +1,88 @@
+package manifest
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		glob    string
+		path    string
+		matched bool
+	}{
+		{"pkg/**", "pkg/api/metadata.go", true},
+		{"pkg/**", "pkgs/api.go", false},
+		{"pkg/*.go", "pkg/api/metadata.go", false},
+		{"pkg/api/*.go", "pkg/api/metadata.go", true},
+		{"**/*dinosaur*", "pkg/dao/dinosaur.go", true},
+		{"**/*dinosaur*", "dinosaur.md", true},
+		{"Dockerfile*", "Dockerfile.test", true},
+		{"go.mod", "go_mod", false},
+		{"pkg/db/?_x.go", "pkg/db/1_x.go", true},
+	}
+	for _, test := range tests {
+		Expect(Match(test.glob, test.path)).To(Equal(test.matched), "%s ~ %s", test.glob, test.path)
+	}
+}
+
+func TestIsFramework(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := &Manifest{
+		Framework: []string{"pkg/**", "Makefile"},
+		Business:  []string{"**/*dinosaur*"},
+	}
+	Expect(m.IsFramework("pkg/dao/generic.go")).To(BeTrue())
+	Expect(m.IsFramework("Makefile")).To(BeTrue())
+	Expect(m.IsFramework("pkg/dao/dinosaur.go")).To(BeFalse())
+	Expect(m.IsFramework("README.md")).To(BeFalse())
+}
+
+func TestReplace(t *testing.T) {
+	RegisterTestingT(t)
+
+	m := &Manifest{
+		Replacements: []Replacement{
+			{From: "github.com/org/fossils", To: "github.com/org/rockets"},
+			{From: "fossil-service", To: "rockets"},
+		},
+	}
+	Expect(m.Replace(`import "github.com/org/fossils/pkg/api"`)).To(Equal(`import "github.com/org/rockets/pkg/api"`))
+	Expect(m.Replace("cmd/fossil-service/main.go")).To(Equal("cmd/rockets/main.go"))
+}
+
+func TestReplacementsTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	from := Identity{
+		Name:        "fossil-service",
+		ShortName:   "fossil-svc",
+		Module:      "github.com/org/fossils",
+		APIPrefix:   "/api/fossil-service",
+		ErrorPrefix: "FOSSIL",
+		DBName:      "fossils",
+	}
+	to := Identity{
+		Name:        "rockets",
+		ShortName:   "rockets",
+		Module:      "github.com/org/rockets",
+		APIPrefix:   "/api/rockets",
+		ErrorPrefix: "ROCKETS",
+		DBName:      "rockets",
+	}
+	m := &Manifest{Replacements: from.ReplacementsTo(to)}
+
+	Expect(m.Replace("/api/fossil-service/v1/dinosaurs")).To(Equal("/api/rockets/v1/dinosaurs"))
+	Expect(m.Replace("'openapi.yaml#/paths/~1api~1fossil-service~1v1~1dinosaurs'")).To(Equal("'openapi.yaml#/paths/~1api~1rockets~1v1~1dinosaurs'"))
+	Expect(m.Replace("client.DefaultApi.ApiFossilServiceV1DinosaursGet(ctx)")).To(Equal("client.DefaultApi.ApiRocketsV1DinosaursGet(ctx)"))
+	Expect(m.Replace("db_user:=fossil_service")).To(Equal("db_user:=rockets"))
+	Expect(m.Replace("db_name:=fossils")).To(Equal("db_name:=rockets"))
+	Expect(m.Replace("IMAGE_NAME=test/fossilservice")).To(Equal("IMAGE_NAME=test/rockets"))
+	Expect(m.Replace("ERROR_CODE_PREFIX = \"FOSSIL\"")).To(Equal("ERROR_CODE_PREFIX = \"ROCKETS\""))
+	Expect(m.Replace("fossil-svc-db")).To(Equal("rockets-db"))
+	Expect(m.Replace(`ID: "fossil"`)).To(Equal(`ID: "rockets"`))
+}
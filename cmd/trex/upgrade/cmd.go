@@ -0,0 +1,91 @@
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/manifest"
+)
+
+var (
+	manifestPath = manifest.DefaultPath
+	upstream     = ""
+	to           = "HEAD"
+	dryRun       = false
+)
+
+// upgrade sub-command merges upstream framework changes into a TRex-derived service
+func NewUpgradeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Merge upstream TRex framework changes into this service",
+		Long: `Merge upstream TRex framework changes into this service.
+
+Every upstream file selected by the framework globs in the manifest is three-way merged, using the
+upstream version recorded in the manifest as the common ancestor. Business logic files are never
+touched. Conflicts are left in the files with the usual conflict markers and reported.`,
+		Run: runUpgrade,
+	}
+
+	cmd.Flags().StringVar(&manifestPath, "manifest", manifestPath, "path to the service's trex manifest, relative to the service root")
+	cmd.Flags().StringVar(&upstream, "upstream", upstream, "upstream TRex checkout or git URL, defaults to the manifest's upstream")
+	cmd.Flags().StringVar(&to, "to", to, "upstream ref to upgrade to")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", dryRun, "report what would change without writing anything")
+	return cmd
+}
+
+func runUpgrade(_ *cobra.Command, _ []string) {
+	m, err := manifest.Load(manifestPath)
+	if err != nil {
+		glog.Fatalf("Unable to load manifest: %s", err)
+	}
+	if m.Version == "" {
+		glog.Fatalf("%s has no upstream version to merge from, set it to the TRex commit this service was created from", manifestPath)
+	}
+
+	if upstream == "" {
+		upstream = m.Upstream
+	}
+	repo, cleanup, err := openUpstream(upstream)
+	if err != nil {
+		glog.Fatalf("Unable to open upstream %s: %s", upstream, err)
+	}
+	defer cleanup()
+
+	root := filepath.Dir(manifestPath)
+	result, err := Upgrade(m, repo, to, root, dryRun)
+	if err != nil {
+		cleanup()
+		glog.Fatalf("Upgrade failed: %s", err)
+	}
+
+	for _, f := range result.Files {
+		fmt.Printf("%-9s %s\n", f.Status, f.Path)
+	}
+	fmt.Printf("upgraded from %s to %s: %d files changed, %d conflicts\n", short(m.Version), short(result.Version), len(result.Files)-len(result.Conflicts()), len(result.Conflicts()))
+
+	if !dryRun {
+		m.Version = result.Version
+		if err := m.Save(manifestPath); err != nil {
+			cleanup()
+			glog.Fatalf("Unable to save manifest: %s", err)
+		}
+	}
+
+	if len(result.Conflicts()) > 0 {
+		cleanup()
+		fmt.Fprintln(os.Stderr, "resolve the conflicts above, then build and test before committing")
+		os.Exit(1)
+	}
+}
+
+func short(version string) string {
+	if len(version) > 12 {
+		return version[:12]
+	}
+	return version
+}
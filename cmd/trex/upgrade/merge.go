@@ -0,0 +1,271 @@
+package upgrade
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/openshift-online/rh-trex/cmd/trex/manifest"
+)
+
+type Status string
+
+const (
+	Added    Status = "added"
+	Updated  Status = "updated"
+	Merged   Status = "merged"
+	Deleted  Status = "deleted"
+	Conflict Status = "conflict"
+)
+
+type FileResult struct {
+	// Path is relative to the service root, after the manifest's replacements
+	Path   string
+	Status Status
+}
+
+type Result struct {
+	// Version is the upstream commit that was merged
+	Version string
+	Files   []FileResult
+}
+
+func (r *Result) Conflicts() []FileResult {
+	var conflicts []FileResult
+	for _, f := range r.Files {
+		if f.Status == Conflict {
+			conflicts = append(conflicts, f)
+		}
+	}
+	return conflicts
+}
+
+// Upgrade three-way merges the framework files of the upstream repo at ref into the service at root.
+// The ancestor of each merge is the same file at the manifest's version. Files that only changed on
+// one side take that side, so only files both upstream and the service changed can conflict.
+func Upgrade(m *manifest.Manifest, repo, ref, root string, dryRun bool) (*Result, error) {
+	version, err := git(repo, "rev-parse", ref+"^{commit}")
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{Version: strings.TrimSpace(string(version))}
+
+	baseFiles, err := listFiles(repo, m.Version)
+	if err != nil {
+		return nil, err
+	}
+	theirFiles, err := listFiles(repo, result.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for path := range union(baseFiles, theirFiles) {
+		if m.IsFramework(path) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		base, err := show(repo, m.Version, path, baseFiles)
+		if err != nil {
+			return nil, err
+		}
+		theirs, err := show(repo, result.Version, path, theirFiles)
+		if err != nil {
+			return nil, err
+		}
+		if base != nil {
+			base = []byte(m.Replace(string(base)))
+		}
+		if theirs != nil {
+			theirs = []byte(m.Replace(string(theirs)))
+		}
+
+		local := filepath.Join(root, m.Replace(path))
+		ours, err := os.ReadFile(local)
+		if os.IsNotExist(err) {
+			ours = nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		status, merged, err := merge(base, ours, theirs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if status == "" {
+			continue
+		}
+		result.Files = append(result.Files, FileResult{Path: m.Replace(path), Status: status})
+
+		if dryRun {
+			continue
+		}
+		if merged == nil {
+			if err := os.Remove(local); err != nil && !os.IsNotExist(err) {
+				return nil, err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(local), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(local, merged, 0644); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// merge decides the new contents of one file. A nil slice means the file does not exist on that
+// side, and a nil merged result means the file should be deleted. An empty status means no change.
+func merge(base, ours, theirs []byte) (Status, []byte, error) {
+	switch {
+	case bytes.Equal(base, theirs) && (base == nil) == (theirs == nil):
+		// upstream didn't change it
+		return "", ours, nil
+	case bytes.Equal(ours, theirs) && (ours == nil) == (theirs == nil):
+		// the service already has the upstream change
+		return "", ours, nil
+	case bytes.Equal(ours, base) && (ours == nil) == (base == nil):
+		// the service didn't change it, take upstream
+		if theirs == nil {
+			return Deleted, nil, nil
+		}
+		if ours == nil {
+			return Added, theirs, nil
+		}
+		return Updated, theirs, nil
+	case ours == nil || theirs == nil:
+		// deleted on one side and changed on the other, keep whichever still exists for review
+		if ours == nil {
+			return Conflict, theirs, nil
+		}
+		return Conflict, ours, nil
+	case isBinary(ours) || isBinary(theirs):
+		return Conflict, ours, nil
+	}
+
+	merged, clean, err := mergeFile(base, ours, theirs)
+	if err != nil {
+		return "", nil, err
+	}
+	if !clean {
+		return Conflict, merged, nil
+	}
+	return Merged, merged, nil
+}
+
+// mergeFile runs git merge-file, which leaves conflict markers in the result
+func mergeFile(base, ours, theirs []byte) ([]byte, bool, error) {
+	dir, err := os.MkdirTemp("", "trex-upgrade-")
+	if err != nil {
+		return nil, false, err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string][]byte{"ours": ours, "base": base, "theirs": theirs}
+	for name, contents := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), contents, 0644); err != nil {
+			return nil, false, err
+		}
+	}
+
+	cmd := exec.Command("git", "merge-file", "-p",
+		"-L", "service", "-L", "base", "-L", "upstream",
+		filepath.Join(dir, "ours"), filepath.Join(dir, "base"), filepath.Join(dir, "theirs"))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 {
+		// a positive exit code is the number of conflicts
+		return stdout.Bytes(), false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("git merge-file: %v: %s", err, stderr.String())
+	}
+	return stdout.Bytes(), true, nil
+}
+
+// openUpstream returns a local git repository for upstream, cloning it first if it is a URL
+func openUpstream(upstream string) (string, func(), error) {
+	if info, err := os.Stat(upstream); err == nil && info.IsDir() {
+		return upstream, func() {}, nil
+	}
+	dir, err := os.MkdirTemp("", "trex-upstream-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	if _, err := git("", "clone", "--quiet", upstream, dir); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
+
+func listFiles(repo, ref string) (map[string]bool, error) {
+	out, err := git(repo, "ls-tree", "-r", "--name-only", ref)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string]bool{}
+	for _, path := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if path != "" {
+			files[path] = true
+		}
+	}
+	return files, nil
+}
+
+func show(repo, ref, path string, files map[string]bool) ([]byte, error) {
+	if !files[path] {
+		return nil, nil
+	}
+	out, err := git(repo, "show", ref+":"+path)
+	if err != nil {
+		return nil, err
+	}
+	// an empty file still exists
+	if out == nil {
+		out = []byte{}
+	}
+	return out, nil
+}
+
+func git(repo string, args ...string) ([]byte, error) {
+	if repo != "" {
+		args = append([]string{"-C", repo}, args...)
+	}
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+func union(a, b map[string]bool) map[string]bool {
+	u := map[string]bool{}
+	for k := range a {
+		u[k] = true
+	}
+	for k := range b {
+		u[k] = true
+	}
+	return u
+}
+
+func isBinary(contents []byte) bool {
+	return bytes.IndexByte(contents, 0) >= 0
+}
@@ -0,0 +1,55 @@
+package upgrade
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMerge(t *testing.T) {
+	RegisterTestingT(t)
+
+	base := []byte("a\nb\nc\n")
+
+	// upstream unchanged, keep the service's edits
+	status, merged, err := merge(base, []byte("a\nB\nc\n"), base)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(BeEmpty())
+	Expect(string(merged)).To(Equal("a\nB\nc\n"))
+
+	// service unchanged, take upstream
+	status, merged, err = merge(base, base, []byte("a\nb\nc\nd\n"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Updated))
+	Expect(string(merged)).To(Equal("a\nb\nc\nd\n"))
+
+	// new upstream file
+	status, merged, err = merge(nil, nil, []byte("new\n"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Added))
+	Expect(string(merged)).To(Equal("new\n"))
+
+	// deleted upstream, unchanged by the service
+	status, merged, err = merge(base, base, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Deleted))
+	Expect(merged).To(BeNil())
+
+	// deleted upstream, changed by the service
+	status, _, err = merge(base, []byte("a\nB\nc\n"), nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Conflict))
+
+	// both changed different lines
+	status, merged, err = merge(base, []byte("A\nb\nc\n"), []byte("a\nb\nC\n"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Merged))
+	Expect(string(merged)).To(Equal("A\nb\nC\n"))
+
+	// both changed the same line
+	status, merged, err = merge(base, []byte("a\nservice\nc\n"), []byte("a\nupstream\nc\n"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(status).To(Equal(Conflict))
+	Expect(string(merged)).To(ContainSubstring("<<<<<<< service"))
+	Expect(string(merged)).To(ContainSubstring(">>>>>>> upstream"))
+}
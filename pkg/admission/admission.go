@@ -0,0 +1,97 @@
+/*
+Package admission lets policies outside the service admit, deny or change the writes of its kinds.
+
+The services call a Chain before each create, update and delete reaches the DAO. The chain sends a Request
+to each hook registered for the kind and the operation, in order, and each hook answers with a Response
+allowing or denying it. A hook allowing a create or an update may also change the object with a JSON patch
+(RFC 6902), which the next hooks see applied and the service then writes.
+
+Hooks are HTTP endpoints the Request is POSTed to, see NewWebhook, or Go funcs registered in process with
+RegisterHook. Each has a timeout, and a FailurePolicy deciding whether the write fails or goes on when the
+hook can't be reached, times out or answers with an invalid patch.
+*/
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+type Operation string
+
+const (
+	Create Operation = "CREATE"
+	Update Operation = "UPDATE"
+	Delete Operation = "DELETE"
+)
+
+// Request is what hooks are asked to admit. Objects are presented as the REST API presents them, as JSON
+// objects of their fields named after their columns, e.g. {"id": "...", "species": "..."}.
+type Request struct {
+	// OperationID is that of the API request making the write
+	OperationID string    `json:"operation_id,omitempty"`
+	Kind        string    `json:"kind"`
+	Operation   Operation `json:"operation"`
+	Username    string    `json:"username,omitempty"`
+	// Object is the object written, with the changes of the hooks before, and is empty for deletes
+	Object json.RawMessage `json:"object,omitempty"`
+	// OldObject is the object before an update, and the object deleted
+	OldObject json.RawMessage `json:"old_object,omitempty"`
+}
+
+type Response struct {
+	Allowed bool `json:"allowed"`
+	// Reason is why the write was denied, returned to the client
+	Reason string `json:"reason,omitempty"`
+	// Patch is a JSON patch to apply to the object, ignored for deletes. The object's id, created_at and
+	// updated_at are set by the service and can't be patched.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// Hook admits requests. An error fails the write, or is ignored, as the hook's FailurePolicy says.
+type Hook interface {
+	Admit(ctx context.Context, request *Request) (*Response, error)
+}
+
+// HookFunc is a Go func used as a Hook
+type HookFunc func(ctx context.Context, request *Request) (*Response, error)
+
+func (f HookFunc) Admit(ctx context.Context, request *Request) (*Response, error) {
+	return f(ctx, request)
+}
+
+// Allow is the response of hooks admitting a request unchanged
+func Allow() *Response {
+	return &Response{Allowed: true}
+}
+
+// Deny is the response of hooks denying a request
+func Deny(reason string, values ...interface{}) *Response {
+	return &Response{Reason: fmt.Sprintf(reason, values...)}
+}
+
+var (
+	hooksMu sync.RWMutex
+	hooks   = map[string]Hook{}
+)
+
+// RegisterHook registers an in-process hook under name, for the admission configuration to refer to.
+// It is meant to be called from init funcs and panics if name is already registered.
+func RegisterHook(name string, hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	if _, ok := hooks[name]; ok {
+		panic(fmt.Sprintf("admission: hook %s registered twice", name))
+	}
+	hooks[name] = hook
+}
+
+// LookupHook finds the in-process hook registered under name
+func LookupHook(name string) (Hook, bool) {
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	hook, ok := hooks[name]
+	return hook, ok
+}
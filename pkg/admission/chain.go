@@ -0,0 +1,276 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"gorm.io/gorm/schema"
+
+	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// FailurePolicy is what happens to a write when its hook fails
+type FailurePolicy string
+
+const (
+	// Fail fails the write, the default
+	Fail FailurePolicy = "Fail"
+	// Ignore goes on with the write as if the hook weren't registered
+	Ignore FailurePolicy = "Ignore"
+)
+
+// DefaultTimeout is how long hooks registered without a timeout have to answer
+const DefaultTimeout = 10 * time.Second
+
+// Registration registers a hook for some kinds and operations
+type Registration struct {
+	Name string
+	Hook Hook
+	// Kinds and Operations are those the hook is called for, all of them when empty
+	Kinds         []string
+	Operations    []Operation
+	Timeout       time.Duration
+	FailurePolicy FailurePolicy
+}
+
+func (r *Registration) matches(kind string, operation Operation) bool {
+	return (len(r.Kinds) == 0 || contains(r.Kinds, kind)) &&
+		(len(r.Operations) == 0 || contains(r.Operations, operation))
+}
+
+func (r *Registration) timeout() time.Duration {
+	if r.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return r.Timeout
+}
+
+func contains[T comparable](values []T, value T) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Chain calls the hooks registered with it, in the order they were registered
+type Chain struct {
+	registrations []Registration
+}
+
+func NewChain(registrations ...Registration) *Chain {
+	return &Chain{registrations: registrations}
+}
+
+// Handles tells whether any hook is registered for kind and operation. The services' writes check it before
+// reading the old object of an update or a delete for Admit.
+func (c *Chain) Handles(kind string, operation Operation) bool {
+	for i := range c.registrations {
+		if c.registrations[i].matches(kind, operation) {
+			return true
+		}
+	}
+	return false
+}
+
+// Admit calls the hooks registered for kind and operation, and fails with the first denial. object points
+// to the api struct written, nil for deletes, and is changed by the hooks' patches. old points to the object
+// before an update or the object deleted, and may be nil when it wasn't read.
+func (c *Chain) Admit(ctx context.Context, kind string, operation Operation, object, old interface{}) *errors.ServiceError {
+	if !c.Handles(kind, operation) {
+		return nil
+	}
+
+	request := &Request{
+		OperationID: logger.GetOperationID(ctx),
+		Kind:        kind,
+		Operation:   operation,
+		Username:    auth.GetUsernameFromContext(ctx),
+	}
+	var err error
+	if !isNil(object) {
+		if request.Object, err = encode(object); err != nil {
+			return errors.GeneralError("Unable to encode the %s for admission: %s", kind, err)
+		}
+	}
+	if !isNil(old) {
+		if request.OldObject, err = encode(old); err != nil {
+			return errors.GeneralError("Unable to encode the %s for admission: %s", kind, err)
+		}
+	}
+
+	for i := range c.registrations {
+		r := &c.registrations[i]
+		if !r.matches(kind, operation) {
+			continue
+		}
+
+		start := time.Now()
+		response, err := call(ctx, r, request)
+		if err == nil && response.Allowed && len(response.Patch) > 0 && !isNil(object) {
+			err = applyPatch(request, response.Patch, object)
+		}
+
+		switch {
+		case err != nil && r.FailurePolicy == Ignore:
+			logger.NewOCMLogger(ctx).Warning(fmt.Sprintf("Ignoring the failure of admission hook %s: %s", r.Name, err))
+			updateAdmissionMetrics(r.Name, kind, operation, resultIgnored, time.Since(start))
+		case err != nil:
+			updateAdmissionMetrics(r.Name, kind, operation, resultFailed, time.Since(start))
+			return errors.GeneralError("Admission hook %s failed: %s", r.Name, err)
+		case !response.Allowed:
+			updateAdmissionMetrics(r.Name, kind, operation, resultDenied, time.Since(start))
+			return errors.Forbidden("%s of %s denied by admission hook %s: %s", operation, kind, r.Name, response.Reason)
+		default:
+			updateAdmissionMetrics(r.Name, kind, operation, resultAllowed, time.Since(start))
+		}
+	}
+	return nil
+}
+
+// isNil tells whether object is nil, or a nil pointer such as an old object that wasn't read
+func isNil(object interface{}) bool {
+	return object == nil || reflect.ValueOf(object).IsNil()
+}
+
+// call calls the hook of r, giving up after its timeout even if the hook doesn't watch its context
+func call(ctx context.Context, r *Registration, request *Request) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout())
+	defer cancel()
+
+	type result struct {
+		response *Response
+		err      error
+	}
+	// the hook is given a copy of the request, which it may still hold when it times out
+	hookRequest := *request
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- result{err: fmt.Errorf("panic: %v", p)}
+			}
+		}()
+		response, err := r.Hook.Admit(ctx, &hookRequest)
+		done <- result{response, err}
+	}()
+
+	select {
+	case res := <-done:
+		if res.err == nil && res.response == nil {
+			res.err = fmt.Errorf("no response")
+		}
+		return res.response, res.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("no response within %s", r.timeout())
+	}
+}
+
+// applyPatch applies patch to the object of request and to object
+func applyPatch(request *Request, patch json.RawMessage, object interface{}) error {
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %s", err)
+	}
+	patched, err := decoded.Apply(request.Object)
+	if err != nil {
+		return fmt.Errorf("unable to apply patch: %s", err)
+	}
+
+	// the object is changed only once the whole patch is known to fit its fields
+	v := reflect.ValueOf(object).Elem()
+	changed := reflect.New(v.Type())
+	changed.Elem().Set(v)
+	if err := decode(patched, changed.Interface()); err != nil {
+		return fmt.Errorf("patched %s", err)
+	}
+	v.Set(changed.Elem())
+
+	// the next hooks see the fields the hooks can't patch unchanged
+	request.Object, err = encode(object)
+	return err
+}
+
+// field is a field of an api struct presented to hooks
+type field struct {
+	column string
+	index  []int
+}
+
+var naming = schema.NamingStrategy{}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// readOnly are the columns of api.Meta set by the service, which the hooks can't patch
+var readOnly = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// fields are the fields of the api struct t presented to hooks: its scalars, not its associations
+func fields(t reflect.Type) []field {
+	var result []field
+	for _, f := range reflect.VisibleFields(t) {
+		// DeletedAt is api.Meta's, which isn't presented
+		if f.Anonymous || !f.IsExported() || f.Name == "DeletedAt" {
+			continue
+		}
+		typ := f.Type
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		switch {
+		case typ == timeType, typ.Implements(marshalerType):
+		case typ.Kind() == reflect.String, typ.Kind() == reflect.Bool:
+		case typ.Kind() >= reflect.Int && typ.Kind() <= reflect.Float64:
+		default:
+			continue
+		}
+		result = append(result, field{column: naming.ColumnName("", f.Name), index: f.Index})
+	}
+	return result
+}
+
+// encode presents the api struct object points to as a JSON object
+func encode(object interface{}) (json.RawMessage, error) {
+	v := reflect.ValueOf(object).Elem()
+	presented := map[string]interface{}{}
+	for _, f := range fields(v.Type()) {
+		presented[f.column] = v.FieldByIndex(f.index).Interface()
+	}
+	return json.Marshal(presented)
+}
+
+// decode sets the fields of the api struct object points to from the JSON object data, but the read only
+// ones. Fields missing from data are zeroed.
+func decode(data []byte, object interface{}) error {
+	var presented map[string]json.RawMessage
+	if err := json.Unmarshal(data, &presented); err != nil {
+		return fmt.Errorf("object is invalid: %s", err)
+	}
+	v := reflect.ValueOf(object).Elem()
+	for _, f := range fields(v.Type()) {
+		if readOnly[f.column] {
+			continue
+		}
+		value := v.FieldByIndex(f.index)
+		raw, ok := presented[f.column]
+		if !ok {
+			value.Set(reflect.Zero(value.Type()))
+			continue
+		}
+		decoded := reflect.New(value.Type())
+		if err := json.Unmarshal(raw, decoded.Interface()); err != nil {
+			return fmt.Errorf("%s is invalid: %s", f.column, err)
+		}
+		value.Set(decoded.Elem())
+	}
+	return nil
+}
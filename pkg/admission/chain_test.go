@@ -0,0 +1,116 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func patch(operations string) HookFunc {
+	return func(ctx context.Context, request *Request) (*Response, error) {
+		return &Response{Allowed: true, Patch: json.RawMessage(operations)}, nil
+	}
+}
+
+func TestAdmitPatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	var seen []string
+	chain := NewChain(
+		Registration{Name: "rename", Hook: patch(`[
+			{"op": "replace", "path": "/species", "value": "Stegosaurus"},
+			{"op": "replace", "path": "/id", "value": "mine"}
+		]`)},
+		Registration{Name: "record", Hook: HookFunc(func(ctx context.Context, request *Request) (*Response, error) {
+			seen = append(seen, string(request.Object), string(request.OldObject))
+			return Allow(), nil
+		})},
+	)
+
+	dinosaur := &api.Dinosaur{Meta: api.Meta{ID: "1", CreatedAt: created, UpdatedAt: created}, Species: "Triceratops"}
+	old := *dinosaur
+	Expect(chain.Admit(context.Background(), "Dinosaur", Update, dinosaur, &old)).To(BeNil())
+
+	// the id is the service's to set
+	Expect(dinosaur.ID).To(Equal("1"))
+	Expect(dinosaur.Species).To(Equal("Stegosaurus"))
+	Expect(seen).To(HaveLen(2))
+	Expect(seen[0]).To(MatchJSON(`{"id": "1", "created_at": "2024-01-02T03:04:05Z", "updated_at": "2024-01-02T03:04:05Z", "species": "Stegosaurus"}`))
+	Expect(seen[1]).To(MatchJSON(`{"id": "1", "created_at": "2024-01-02T03:04:05Z", "updated_at": "2024-01-02T03:04:05Z", "species": "Triceratops"}`))
+}
+
+func TestAdmitDenied(t *testing.T) {
+	RegisterTestingT(t)
+
+	called := false
+	chain := NewChain(
+		Registration{Name: "policy", Kinds: []string{"Dinosaur"}, Operations: []Operation{Create}, Hook: HookFunc(func(ctx context.Context, request *Request) (*Response, error) {
+			return Deny("no %s here", "Triceratops"), nil
+		})},
+		Registration{Name: "after", Hook: HookFunc(func(ctx context.Context, request *Request) (*Response, error) {
+			called = true
+			return Allow(), nil
+		})},
+	)
+	Expect(chain.Handles("Dinosaur", Create)).To(BeTrue())
+	Expect(chain.Handles("Egg", Delete)).To(BeTrue())
+
+	err := chain.Admit(context.Background(), "Dinosaur", Create, &api.Dinosaur{Species: "Triceratops"}, nil)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Code).To(Equal(errors.ErrorForbidden))
+	Expect(err.Reason).To(Equal("CREATE of Dinosaur denied by admission hook policy: no Triceratops here"))
+	Expect(called).To(BeFalse())
+
+	// the policy isn't registered for deletes, nor updates of which the old object wasn't read
+	Expect(chain.Admit(context.Background(), "Dinosaur", Delete, nil, &api.Dinosaur{})).To(BeNil())
+	var old *api.Dinosaur
+	Expect(chain.Admit(context.Background(), "Dinosaur", Update, &api.Dinosaur{}, old)).To(BeNil())
+	Expect(called).To(BeTrue())
+}
+
+func TestAdmitFailurePolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	slow := HookFunc(func(ctx context.Context, request *Request) (*Response, error) {
+		// ignores its context, but is given up on all the same
+		time.Sleep(time.Second)
+		return Allow(), nil
+	})
+	dinosaur := &api.Dinosaur{Species: "Triceratops"}
+
+	chain := NewChain(Registration{Name: "slow", Hook: slow, Timeout: 10 * time.Millisecond})
+	err := chain.Admit(context.Background(), "Dinosaur", Create, dinosaur, nil)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Code).To(Equal(errors.ErrorGeneral))
+	Expect(err.Reason).To(Equal("Admission hook slow failed: no response within 10ms"))
+
+	chain = NewChain(
+		Registration{Name: "slow", Hook: slow, Timeout: 10 * time.Millisecond, FailurePolicy: Ignore},
+		Registration{Name: "invalid", Hook: patch(`[{"op": "replace", "path": "/species", "value": 1}]`), FailurePolicy: Ignore},
+	)
+	Expect(chain.Admit(context.Background(), "Dinosaur", Create, dinosaur, nil)).To(BeNil())
+	// the patch of the ignored hook isn't applied
+	Expect(dinosaur.Species).To(Equal("Triceratops"))
+
+	chain = NewChain(Registration{Name: "invalid", Hook: patch(`[{"op": "remove", "path": "/weight"}]`)})
+	err = chain.Admit(context.Background(), "Dinosaur", Create, dinosaur, nil)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Reason).To(HavePrefix("Admission hook invalid failed: unable to apply patch"))
+}
+
+func TestAdmitProperties(t *testing.T) {
+	RegisterTestingT(t)
+
+	chain := NewChain(Registration{Name: "defaults", Hook: patch(`[{"op": "add", "path": "/properties/legs", "value": 4}]`)})
+	resource := &api.CustomResource{Kind: "widget", Properties: api.JSON(`{"name": "a"}`)}
+	Expect(chain.Admit(context.Background(), "widget", Create, resource, nil)).To(BeNil())
+	Expect(string(resource.Properties)).To(MatchJSON(`{"name": "a", "legs": 4}`))
+}
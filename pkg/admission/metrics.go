@@ -0,0 +1,76 @@
+package admission
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "admission"
+
+// Names of the labels added to metrics:
+const (
+	metricsHookLabel      = "hook"
+	metricsKindLabel      = "kind"
+	metricsOperationLabel = "operation"
+	metricsResultLabel    = "result"
+)
+
+// Results of the calls to hooks:
+const (
+	resultAllowed = "allowed"
+	resultDenied  = "denied"
+	// the hook failed and the write failed with it
+	resultFailed = "failed"
+	// the hook failed and its failure policy ignored it
+	resultIgnored = "ignored"
+)
+
+// Description of the hook calls metric:
+var hookCallsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "hook_calls",
+		Help:      "Number of calls to admission hooks, by their result.",
+	},
+	[]string{metricsHookLabel, metricsKindLabel, metricsOperationLabel, metricsResultLabel},
+)
+
+// Description of the hook duration metric:
+var hookDurationMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "hook_duration",
+		Help:      "Duration in seconds of the calls to admission hooks.",
+		Buckets: []float64{
+			0.005,
+			0.01,
+			0.05,
+			0.1,
+			0.5,
+			1.0,
+			5.0,
+			10.0,
+		},
+	},
+	[]string{metricsHookLabel, metricsResultLabel},
+)
+
+func init() {
+	prometheus.MustRegister(hookCallsMetric)
+	prometheus.MustRegister(hookDurationMetric)
+}
+
+func updateAdmissionMetrics(hook, kind string, operation Operation, result string, duration time.Duration) {
+	hookCallsMetric.With(prometheus.Labels{
+		metricsHookLabel:      hook,
+		metricsKindLabel:      kind,
+		metricsOperationLabel: string(operation),
+		metricsResultLabel:    result,
+	}).Inc()
+	hookDurationMetric.With(prometheus.Labels{
+		metricsHookLabel:   hook,
+		metricsResultLabel: result,
+	}).Observe(duration.Seconds())
+}
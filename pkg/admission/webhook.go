@@ -0,0 +1,64 @@
+package admission
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxResponseSize bounds the responses read from webhooks
+const maxResponseSize = 1 << 20
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook is a hook POSTing the Request as JSON to url, which answers with the Response as JSON and a
+// 200 status. The request is cancelled when the hook times out.
+func NewWebhook(url string, client *http.Client) Hook {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &webhook{url: url, client: client}
+}
+
+func (w *webhook) Admit(ctx context.Context, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if request.OperationID != "" {
+		httpRequest.Header.Set("X-Operation-ID", request.OperationID)
+	}
+
+	httpResponse, err := w.client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			return nil, fmt.Errorf("%s answered %d: %s", w.url, httpResponse.StatusCode, data)
+		}
+		return nil, fmt.Errorf("%s answered %d", w.url, httpResponse.StatusCode)
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("%s answered an invalid response: %s", w.url, err)
+	}
+	return response, nil
+}
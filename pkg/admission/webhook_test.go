@@ -0,0 +1,44 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+)
+
+func TestWebhook(t *testing.T) {
+	RegisterTestingT(t)
+
+	var received Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if received.Operation == Delete {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"allowed": true, "patch": [{"op": "replace", "path": "/species", "value": "Stegosaurus"}]}`))
+	}))
+	defer server.Close()
+
+	chain := NewChain(Registration{Name: "species", Hook: NewWebhook(server.URL, nil), Timeout: time.Second})
+	dinosaur := &api.Dinosaur{Species: "Triceratops"}
+	Expect(chain.Admit(context.Background(), "Dinosaur", Create, dinosaur, nil)).To(BeNil())
+	Expect(dinosaur.Species).To(Equal("Stegosaurus"))
+	Expect(received.Kind).To(Equal("Dinosaur"))
+	Expect(received.Operation).To(Equal(Create))
+	Expect(string(received.Object)).To(MatchJSON(`{"id": "", "created_at": "0001-01-01T00:00:00Z", "updated_at": "0001-01-01T00:00:00Z", "species": "Triceratops"}`))
+
+	err := chain.Admit(context.Background(), "Dinosaur", Delete, nil, dinosaur)
+	Expect(err).NotTo(BeNil())
+	Expect(err.Reason).To(Equal("Admission hook species failed: " + server.URL + " answered 500"))
+}
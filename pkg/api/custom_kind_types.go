@@ -0,0 +1,51 @@
+package api
+
+import "gorm.io/gorm"
+
+// CustomKind is a kind of resource defined at runtime rather than generated, e.g. feature_flag. Its
+// resources are CustomResources, whose properties must match its JSON Schema.
+type CustomKind struct {
+	Meta
+	Name   string
+	Schema JSON `gorm:"type:jsonb"`
+}
+
+type CustomKindList []*CustomKind
+type CustomKindIndex map[string]*CustomKind
+
+func (l CustomKindList) Index() CustomKindIndex {
+	index := CustomKindIndex{}
+	for _, o := range l {
+		index[o.ID] = o
+	}
+	return index
+}
+
+func (k *CustomKind) BeforeCreate(tx *gorm.DB) error {
+	k.ID = NewID()
+	return nil
+}
+
+// CustomResource is a resource of a CustomKind. The resources of all the custom kinds share a table,
+// their properties are searched with properties.<name>, e.g. properties.enabled = 'true'.
+type CustomResource struct {
+	Meta
+	Kind       string
+	Properties JSON `gorm:"type:jsonb"`
+}
+
+type CustomResourceList []*CustomResource
+type CustomResourceIndex map[string]*CustomResource
+
+func (l CustomResourceList) Index() CustomResourceIndex {
+	index := CustomResourceIndex{}
+	for _, o := range l {
+		index[o.ID] = o
+	}
+	return index
+}
+
+func (r *CustomResource) BeforeCreate(tx *gorm.DB) error {
+	r.ID = NewID()
+	return nil
+}
@@ -25,8 +25,8 @@ func SendNotFound(w http.ResponseWriter, r *http.Request) {
 	body := Error{
 		Type:   ErrorType,
 		ID:     id,
-		HREF:   "/api/ocm-example-service/v1/errors/" + id,
-		Code:   "OCM-EX-" + id,
+		HREF:   errors.ERROR_HREF + id,
+		Code:   errors.ERROR_CODE_PREFIX + "-" + id,
 		Reason: reason,
 	}
 	data, err := json.Marshal(body)
@@ -96,8 +96,8 @@ func init() {
 	panicError := Error{
 		Type: ErrorType,
 		ID:   panicID,
-		HREF: "/api/ocm-example-service/v1/" + panicID,
-		Code: "OCM-EX-" + panicID,
+		HREF: errors.ERROR_HREF + panicID,
+		Code: errors.ERROR_CODE_PREFIX + "-" + panicID,
 		Reason: "An unexpected error happened, please check the log of the service " +
 			"for details",
 	}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+func TestSendNotFound(t *testing.T) {
+	RegisterTestingT(t)
+
+	w := httptest.NewRecorder()
+	SendNotFound(w, httptest.NewRequest(http.MethodGet, "/nowhere", nil))
+	Expect(w.Code).To(Equal(http.StatusNotFound))
+
+	body := Error{}
+	Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+	// not found errors look like every other service error
+	Expect(body.Code).To(Equal(errors.ERROR_CODE_PREFIX + "-404"))
+	Expect(body.HREF).To(Equal(errors.ERROR_HREF + "404"))
+}
+
+func TestPanicBody(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := Error{}
+	Expect(json.Unmarshal(panicBody, &body)).To(Succeed())
+	Expect(body.Code).To(Equal(errors.ERROR_CODE_PREFIX + "-1000"))
+	Expect(body.HREF).To(Equal(errors.ERROR_HREF + "1000"))
+}
@@ -25,6 +25,8 @@ import (
 
 	"github.com/getsentry/sentry-go"
 	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 // SendAPI sends API documentation response.
@@ -40,7 +42,7 @@ func SendAPI(w http.ResponseWriter, r *http.Request) {
 		},
 	}
 	body := Metadata{
-		ID:       "ocm_example",
+		ID:       identity.ID,
 		Kind:     "API",
 		HREF:     r.URL.Path,
 		Versions: versions,
@@ -0,0 +1,12 @@
+package api
+
+import "time"
+
+// OutboxOffset is how far a publisher has got through the events: it has published every event up to and
+// including EventID, in the order of their creation.
+type OutboxOffset struct {
+	Publisher      string `gorm:"primaryKey"`
+	EventID        string
+	EventCreatedAt time.Time
+	UpdatedAt      time.Time
+}
@@ -0,0 +1,834 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: pkg/api/pb/dinosaurs.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Dinosaur struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind      string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Href      string                 `protobuf:"bytes,3,opt,name=href,proto3" json:"href,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Species   string                 `protobuf:"bytes,6,opt,name=species,proto3" json:"species,omitempty"`
+}
+
+func (x *Dinosaur) Reset() {
+	*x = Dinosaur{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dinosaur) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dinosaur) ProtoMessage() {}
+
+func (x *Dinosaur) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dinosaur.ProtoReflect.Descriptor instead.
+func (*Dinosaur) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Dinosaur) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dinosaur) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Dinosaur) GetHref() string {
+	if x != nil {
+		return x.Href
+	}
+	return ""
+}
+
+func (x *Dinosaur) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Dinosaur) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Dinosaur) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+type GetDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetDinosaurRequest) Reset() {
+	*x = GetDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDinosaurRequest) ProtoMessage() {}
+
+func (x *GetDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*GetDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDinosaurRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDinosaursRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// search is a TSL query, e.g. species = 'Stegosaurus', as the search parameter of the REST API
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	// order_by lists the fields to sort by, each optionally followed by asc or desc
+	OrderBy []string `protobuf:"bytes,2,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// page_size is the number of dinosaurs to return, 100 if unset
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, for the same search and order_by
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListDinosaursRequest) Reset() {
+	*x = ListDinosaursRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDinosaursRequest) ProtoMessage() {}
+
+func (x *ListDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*ListDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListDinosaursRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListDinosaursRequest) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListDinosaursRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListDinosaursResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaurs []*Dinosaur `protobuf:"bytes,1,rep,name=dinosaurs,proto3" json:"dinosaurs,omitempty"`
+	// next_page_token fetches the next page, it is empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total is the number of dinosaurs matching the search
+	Total int64 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *ListDinosaursResponse) Reset() {
+	*x = ListDinosaursResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDinosaursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDinosaursResponse) ProtoMessage() {}
+
+func (x *ListDinosaursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDinosaursResponse.ProtoReflect.Descriptor instead.
+func (*ListDinosaursResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListDinosaursResponse) GetDinosaurs() []*Dinosaur {
+	if x != nil {
+		return x.Dinosaurs
+	}
+	return nil
+}
+
+func (x *ListDinosaursResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListDinosaursResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CreateDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaur *Dinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+}
+
+func (x *CreateDinosaurRequest) Reset() {
+	*x = CreateDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateDinosaurRequest) ProtoMessage() {}
+
+func (x *CreateDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*CreateDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateDinosaurRequest) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+// UpdateDinosaurRequest changes the fields that are set, as the REST API's DinosaurPatchRequest
+type UpdateDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Species *string `protobuf:"bytes,2,opt,name=species,proto3,oneof" json:"species,omitempty"`
+}
+
+func (x *UpdateDinosaurRequest) Reset() {
+	*x = UpdateDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDinosaurRequest) ProtoMessage() {}
+
+func (x *UpdateDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateDinosaurRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateDinosaurRequest) GetSpecies() string {
+	if x != nil && x.Species != nil {
+		return *x.Species
+	}
+	return ""
+}
+
+type DeleteDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteDinosaurRequest) Reset() {
+	*x = DeleteDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDinosaurRequest) ProtoMessage() {}
+
+func (x *DeleteDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteDinosaurRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchDinosaursRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WatchDinosaursRequest) Reset() {
+	*x = WatchDinosaursRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDinosaursRequest) ProtoMessage() {}
+
+func (x *WatchDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*WatchDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{7}
+}
+
+type WatchDinosaursResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=ocm_example.v1.EventType" json:"type,omitempty"`
+	// dinosaur is the dinosaur as it is after the event, only its id is set for deletes
+	Dinosaur *Dinosaur `protobuf:"bytes,2,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+}
+
+func (x *WatchDinosaursResponse) Reset() {
+	*x = WatchDinosaursResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDinosaursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDinosaursResponse) ProtoMessage() {}
+
+func (x *WatchDinosaursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_pb_dinosaurs_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDinosaursResponse.ProtoReflect.Descriptor instead.
+func (*WatchDinosaursResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_pb_dinosaurs_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WatchDinosaursResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *WatchDinosaursResponse) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+var File_pkg_api_pb_dinosaurs_proto protoreflect.FileDescriptor
+
+var file_pkg_api_pb_dinosaurs_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x64, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x6f, 0x63,
+	0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1b, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d,
+	0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x70, 0x6b, 0x67, 0x2f,
+	0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xd2, 0x01, 0x0a, 0x08, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x72, 0x65, 0x66, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x85,
+	0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
+	0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8d, 0x01, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x09, 0x64,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x4d, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x34, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x08, 0x64, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x22, 0x52, 0x0a, 0x15, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1d,
+	0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a,
+	0x08, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x22, 0x27, 0x0a, 0x15, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x7d, 0x0a, 0x16, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x34, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x52, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x32, 0xde, 0x03, 0x0a, 0x09, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12,
+	0x22, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x53, 0x0a,
+	0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x24, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6f, 0x63,
+	0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x49, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x25, 0x2e, 0x6f,
+	0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x49, 0x0a,
+	0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x25, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x47, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x12, 0x25, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x12, 0x58, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68, 0x12, 0x25, 0x2e, 0x6f, 0x63, 0x6d,
+	0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x26, 0x2e, 0x6f, 0x63, 0x6d, 0x5f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x42, 0x30, 0x5a, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x73, 0x68,
+	0x69, 0x66, 0x74, 0x2d, 0x6f, 0x6e, 0x6c, 0x69, 0x6e, 0x65, 0x2f, 0x72, 0x68, 0x2d, 0x74, 0x72,
+	0x65, 0x78, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pkg_api_pb_dinosaurs_proto_rawDescOnce sync.Once
+	file_pkg_api_pb_dinosaurs_proto_rawDescData = file_pkg_api_pb_dinosaurs_proto_rawDesc
+)
+
+func file_pkg_api_pb_dinosaurs_proto_rawDescGZIP() []byte {
+	file_pkg_api_pb_dinosaurs_proto_rawDescOnce.Do(func() {
+		file_pkg_api_pb_dinosaurs_proto_rawDescData = protoimpl.X.CompressGZIP(file_pkg_api_pb_dinosaurs_proto_rawDescData)
+	})
+	return file_pkg_api_pb_dinosaurs_proto_rawDescData
+}
+
+var file_pkg_api_pb_dinosaurs_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_pkg_api_pb_dinosaurs_proto_goTypes = []interface{}{
+	(*Dinosaur)(nil),               // 0: ocm_example.v1.Dinosaur
+	(*GetDinosaurRequest)(nil),     // 1: ocm_example.v1.GetDinosaurRequest
+	(*ListDinosaursRequest)(nil),   // 2: ocm_example.v1.ListDinosaursRequest
+	(*ListDinosaursResponse)(nil),  // 3: ocm_example.v1.ListDinosaursResponse
+	(*CreateDinosaurRequest)(nil),  // 4: ocm_example.v1.CreateDinosaurRequest
+	(*UpdateDinosaurRequest)(nil),  // 5: ocm_example.v1.UpdateDinosaurRequest
+	(*DeleteDinosaurRequest)(nil),  // 6: ocm_example.v1.DeleteDinosaurRequest
+	(*WatchDinosaursRequest)(nil),  // 7: ocm_example.v1.WatchDinosaursRequest
+	(*WatchDinosaursResponse)(nil), // 8: ocm_example.v1.WatchDinosaursResponse
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(EventType)(0),                 // 10: ocm_example.v1.EventType
+	(*emptypb.Empty)(nil),          // 11: google.protobuf.Empty
+}
+var file_pkg_api_pb_dinosaurs_proto_depIdxs = []int32{
+	9,  // 0: ocm_example.v1.Dinosaur.created_at:type_name -> google.protobuf.Timestamp
+	9,  // 1: ocm_example.v1.Dinosaur.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: ocm_example.v1.ListDinosaursResponse.dinosaurs:type_name -> ocm_example.v1.Dinosaur
+	0,  // 3: ocm_example.v1.CreateDinosaurRequest.dinosaur:type_name -> ocm_example.v1.Dinosaur
+	10, // 4: ocm_example.v1.WatchDinosaursResponse.type:type_name -> ocm_example.v1.EventType
+	0,  // 5: ocm_example.v1.WatchDinosaursResponse.dinosaur:type_name -> ocm_example.v1.Dinosaur
+	1,  // 6: ocm_example.v1.Dinosaurs.Get:input_type -> ocm_example.v1.GetDinosaurRequest
+	2,  // 7: ocm_example.v1.Dinosaurs.List:input_type -> ocm_example.v1.ListDinosaursRequest
+	4,  // 8: ocm_example.v1.Dinosaurs.Create:input_type -> ocm_example.v1.CreateDinosaurRequest
+	5,  // 9: ocm_example.v1.Dinosaurs.Update:input_type -> ocm_example.v1.UpdateDinosaurRequest
+	6,  // 10: ocm_example.v1.Dinosaurs.Delete:input_type -> ocm_example.v1.DeleteDinosaurRequest
+	7,  // 11: ocm_example.v1.Dinosaurs.Watch:input_type -> ocm_example.v1.WatchDinosaursRequest
+	0,  // 12: ocm_example.v1.Dinosaurs.Get:output_type -> ocm_example.v1.Dinosaur
+	3,  // 13: ocm_example.v1.Dinosaurs.List:output_type -> ocm_example.v1.ListDinosaursResponse
+	0,  // 14: ocm_example.v1.Dinosaurs.Create:output_type -> ocm_example.v1.Dinosaur
+	0,  // 15: ocm_example.v1.Dinosaurs.Update:output_type -> ocm_example.v1.Dinosaur
+	11, // 16: ocm_example.v1.Dinosaurs.Delete:output_type -> google.protobuf.Empty
+	8,  // 17: ocm_example.v1.Dinosaurs.Watch:output_type -> ocm_example.v1.WatchDinosaursResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_pb_dinosaurs_proto_init() }
+func file_pkg_api_pb_dinosaurs_proto_init() {
+	if File_pkg_api_pb_dinosaurs_proto != nil {
+		return
+	}
+	file_pkg_api_pb_events_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dinosaur); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDinosaursRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDinosaursResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDinosaursRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_pb_dinosaurs_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchDinosaursResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_pkg_api_pb_dinosaurs_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pkg_api_pb_dinosaurs_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_api_pb_dinosaurs_proto_goTypes,
+		DependencyIndexes: file_pkg_api_pb_dinosaurs_proto_depIdxs,
+		MessageInfos:      file_pkg_api_pb_dinosaurs_proto_msgTypes,
+	}.Build()
+	File_pkg_api_pb_dinosaurs_proto = out.File
+	file_pkg_api_pb_dinosaurs_proto_rawDesc = nil
+	file_pkg_api_pb_dinosaurs_proto_goTypes = nil
+	file_pkg_api_pb_dinosaurs_proto_depIdxs = nil
+}
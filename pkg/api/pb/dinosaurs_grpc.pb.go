@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pkg/api/pb/dinosaurs.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Dinosaurs_Get_FullMethodName    = "/ocm_example.v1.Dinosaurs/Get"
+	Dinosaurs_List_FullMethodName   = "/ocm_example.v1.Dinosaurs/List"
+	Dinosaurs_Create_FullMethodName = "/ocm_example.v1.Dinosaurs/Create"
+	Dinosaurs_Update_FullMethodName = "/ocm_example.v1.Dinosaurs/Update"
+	Dinosaurs_Delete_FullMethodName = "/ocm_example.v1.Dinosaurs/Delete"
+	Dinosaurs_Watch_FullMethodName  = "/ocm_example.v1.Dinosaurs/Watch"
+)
+
+// DinosaursClient is the client API for Dinosaurs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DinosaursClient interface {
+	Get(ctx context.Context, in *GetDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error)
+	// List pages through the dinosaurs, filtered and sorted as the REST API's list is
+	List(ctx context.Context, in *ListDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error)
+	Create(ctx context.Context, in *CreateDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error)
+	Update(ctx context.Context, in *UpdateDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error)
+	Delete(ctx context.Context, in *DeleteDinosaurRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Watch streams the dinosaurs created, updated and deleted from the time it is called
+	Watch(ctx context.Context, in *WatchDinosaursRequest, opts ...grpc.CallOption) (Dinosaurs_WatchClient, error)
+}
+
+type dinosaursClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDinosaursClient(cc grpc.ClientConnInterface) DinosaursClient {
+	return &dinosaursClient{cc}
+}
+
+func (c *dinosaursClient) Get(ctx context.Context, in *GetDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error) {
+	out := new(Dinosaur)
+	err := c.cc.Invoke(ctx, Dinosaurs_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaursClient) List(ctx context.Context, in *ListDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error) {
+	out := new(ListDinosaursResponse)
+	err := c.cc.Invoke(ctx, Dinosaurs_List_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaursClient) Create(ctx context.Context, in *CreateDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error) {
+	out := new(Dinosaur)
+	err := c.cc.Invoke(ctx, Dinosaurs_Create_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaursClient) Update(ctx context.Context, in *UpdateDinosaurRequest, opts ...grpc.CallOption) (*Dinosaur, error) {
+	out := new(Dinosaur)
+	err := c.cc.Invoke(ctx, Dinosaurs_Update_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaursClient) Delete(ctx context.Context, in *DeleteDinosaurRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Dinosaurs_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaursClient) Watch(ctx context.Context, in *WatchDinosaursRequest, opts ...grpc.CallOption) (Dinosaurs_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dinosaurs_ServiceDesc.Streams[0], Dinosaurs_Watch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dinosaursWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Dinosaurs_WatchClient interface {
+	Recv() (*WatchDinosaursResponse, error)
+	grpc.ClientStream
+}
+
+type dinosaursWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *dinosaursWatchClient) Recv() (*WatchDinosaursResponse, error) {
+	m := new(WatchDinosaursResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// DinosaursServer is the server API for Dinosaurs service.
+// All implementations must embed UnimplementedDinosaursServer
+// for forward compatibility
+type DinosaursServer interface {
+	Get(context.Context, *GetDinosaurRequest) (*Dinosaur, error)
+	// List pages through the dinosaurs, filtered and sorted as the REST API's list is
+	List(context.Context, *ListDinosaursRequest) (*ListDinosaursResponse, error)
+	Create(context.Context, *CreateDinosaurRequest) (*Dinosaur, error)
+	Update(context.Context, *UpdateDinosaurRequest) (*Dinosaur, error)
+	Delete(context.Context, *DeleteDinosaurRequest) (*emptypb.Empty, error)
+	// Watch streams the dinosaurs created, updated and deleted from the time it is called
+	Watch(*WatchDinosaursRequest, Dinosaurs_WatchServer) error
+	mustEmbedUnimplementedDinosaursServer()
+}
+
+// UnimplementedDinosaursServer must be embedded to have forward compatible implementations.
+type UnimplementedDinosaursServer struct {
+}
+
+func (UnimplementedDinosaursServer) Get(context.Context, *GetDinosaurRequest) (*Dinosaur, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedDinosaursServer) List(context.Context, *ListDinosaursRequest) (*ListDinosaursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedDinosaursServer) Create(context.Context, *CreateDinosaurRequest) (*Dinosaur, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedDinosaursServer) Update(context.Context, *UpdateDinosaurRequest) (*Dinosaur, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedDinosaursServer) Delete(context.Context, *DeleteDinosaurRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedDinosaursServer) Watch(*WatchDinosaursRequest, Dinosaurs_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedDinosaursServer) mustEmbedUnimplementedDinosaursServer() {}
+
+// UnsafeDinosaursServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DinosaursServer will
+// result in compilation errors.
+type UnsafeDinosaursServer interface {
+	mustEmbedUnimplementedDinosaursServer()
+}
+
+func RegisterDinosaursServer(s grpc.ServiceRegistrar, srv DinosaursServer) {
+	s.RegisterService(&Dinosaurs_ServiceDesc, srv)
+}
+
+func _Dinosaurs_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaursServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dinosaurs_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaursServer).Get(ctx, req.(*GetDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dinosaurs_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaursServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dinosaurs_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaursServer).List(ctx, req.(*ListDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dinosaurs_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaursServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dinosaurs_Create_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaursServer).Create(ctx, req.(*CreateDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dinosaurs_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaursServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dinosaurs_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaursServer).Update(ctx, req.(*UpdateDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dinosaurs_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaursServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dinosaurs_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaursServer).Delete(ctx, req.(*DeleteDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dinosaurs_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDinosaursRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DinosaursServer).Watch(m, &dinosaursWatchServer{stream})
+}
+
+type Dinosaurs_WatchServer interface {
+	Send(*WatchDinosaursResponse) error
+	grpc.ServerStream
+}
+
+type dinosaursWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *dinosaursWatchServer) Send(m *WatchDinosaursResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Dinosaurs_ServiceDesc is the grpc.ServiceDesc for Dinosaurs service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Dinosaurs_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ocm_example.v1.Dinosaurs",
+	HandlerType: (*DinosaursServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _Dinosaurs_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Dinosaurs_List_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _Dinosaurs_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Dinosaurs_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Dinosaurs_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Dinosaurs_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/api/pb/dinosaurs.proto",
+}
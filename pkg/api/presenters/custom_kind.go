@@ -0,0 +1,74 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/util"
+)
+
+func ConvertCustomKind(kind openapi.CustomKind) *api.CustomKind {
+	return &api.CustomKind{
+		Meta: api.Meta{
+			ID: util.NilToEmptyString(kind.Id),
+		},
+		Name:   util.NilToEmptyString(kind.Name),
+		Schema: convertObject(kind.Schema),
+	}
+}
+
+// PresentCustomKind presents kind, which is referred to by name rather than id
+func PresentCustomKind(kind *api.CustomKind) openapi.CustomKind {
+	return openapi.CustomKind{
+		Id:        openapi.PtrString(kind.ID),
+		Kind:      openapi.PtrString("CustomKind"),
+		Href:      openapi.PtrString(fmt.Sprintf("%s/custom_kinds/%s", BasePath, kind.Name)),
+		Name:      openapi.PtrString(kind.Name),
+		Schema:    presentObject(kind.Schema),
+		CreatedAt: openapi.PtrTime(kind.CreatedAt),
+		UpdatedAt: openapi.PtrTime(kind.UpdatedAt),
+	}
+}
+
+func ConvertCustomResource(kind string, resource openapi.CustomResource) *api.CustomResource {
+	return &api.CustomResource{
+		Meta: api.Meta{
+			ID: util.NilToEmptyString(resource.Id),
+		},
+		Kind:       kind,
+		Properties: convertObject(resource.Properties),
+	}
+}
+
+// PresentCustomResource presents resource as of its custom kind, rather than as a CustomResource
+func PresentCustomResource(resource *api.CustomResource) openapi.CustomResource {
+	return openapi.CustomResource{
+		Id:         openapi.PtrString(resource.ID),
+		Kind:       openapi.PtrString(resource.Kind),
+		Href:       openapi.PtrString(fmt.Sprintf("%s/custom_kinds/%s/resources/%s", BasePath, resource.Kind, resource.ID)),
+		Properties: presentObject(resource.Properties),
+		CreatedAt:  openapi.PtrTime(resource.CreatedAt),
+		UpdatedAt:  openapi.PtrTime(resource.UpdatedAt),
+	}
+}
+
+// convertObject converts a JSON object of a request, nil if it was missing
+func convertObject(object map[string]interface{}) api.JSON {
+	if object == nil {
+		return nil
+	}
+	// it was unmarshaled from JSON, so marshals back
+	data, _ := json.Marshal(object)
+	return data
+}
+
+// presentObject presents a JSON object of the database, where it was validated as one
+func presentObject(object api.JSON) map[string]interface{} {
+	var result map[string]interface{}
+	if err := json.Unmarshal(object, &result); err != nil {
+		return nil
+	}
+	return result
+}
@@ -11,6 +11,7 @@ func ObjectKind(i interface{}) *string {
 	switch i.(type) {
 	case api.Dinosaur, *api.Dinosaur:
 		result = "Dinosaur"
+	// +trex:scaffold:kinds
 	case errors.ServiceError, *errors.ServiceError:
 		result = "Error"
 	}
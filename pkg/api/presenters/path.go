@@ -7,10 +7,11 @@ import (
 
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 const (
-	BasePath = "/api/ocm-example-service/v1"
+	BasePath = identity.BasePath
 )
 
 func ObjectPath(id string, obj interface{}) *string {
@@ -21,6 +22,7 @@ func path(i interface{}) string {
 	switch i.(type) {
 	case api.Dinosaur, *api.Dinosaur:
 		return "dinosaurs"
+	// +trex:scaffold:paths
 	case errors.ServiceError, *errors.ServiceError:
 		return "errors"
 	default:
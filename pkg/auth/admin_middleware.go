@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// AdminMiddleware restricts routes to the admins of the service, e.g. those that define custom kinds
+type AdminMiddleware interface {
+	AuthorizeAdmin(next http.Handler) http.Handler
+}
+
+type adminMiddleware struct {
+	// struct{} doesn't occupy any additional space
+	admins map[string]struct{}
+}
+
+var _ AdminMiddleware = &adminMiddleware{}
+
+// NewAdminMiddleware creates an AdminMiddleware that lets the users named by admins through
+func NewAdminMiddleware(admins []string) AdminMiddleware {
+	set := make(map[string]struct{}, len(admins))
+	for _, admin := range admins {
+		set[admin] = struct{}{}
+	}
+	return &adminMiddleware{admins: set}
+}
+
+// AuthorizeAdmin answers 403 to the requests of anyone but the admins. It goes after the authentication,
+// whose username it reads from the context of the request.
+func (a adminMiddleware) AuthorizeAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		username := GetUsernameFromContext(ctx)
+		if _, ok := a.admins[username]; !ok || username == "" {
+			handleError(ctx, w, errors.ErrorForbidden, "Only admins are allowed to perform this action")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
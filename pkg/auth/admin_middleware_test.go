@@ -0,0 +1,31 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAuthorizeAdmin(t *testing.T) {
+	RegisterTestingT(t)
+
+	handler := NewAdminMiddleware([]string{"alice"}).AuthorizeAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	for username, code := range map[string]int{
+		"alice": http.StatusCreated,
+		"bob":   http.StatusForbidden,
+		// requests that weren't authenticated have no username
+		"": http.StatusForbidden,
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/custom_kinds", nil)
+		r = r.WithContext(SetUsernameContext(context.Background(), username))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		Expect(w.Code).To(Equal(code), "username %q", username)
+	}
+}
@@ -0,0 +1,34 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// BypassHeader is the header of the requests whose reads skip the cache, to tell whether a stale object
+// comes from it while debugging
+const BypassHeader = "X-Cache-Bypass"
+
+type bypassKey struct{}
+
+// WithBypass returns a context whose reads skip the cache
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// Bypassed tells whether the reads of ctx skip the cache
+func Bypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(bypassKey{}).(bool)
+	return bypassed
+}
+
+// BypassMiddleware has the reads of the requests with a true BypassHeader skip the cache
+func BypassMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if bypass, _ := strconv.ParseBool(r.Header.Get(BypassHeader)); bypass {
+			r = r.WithContext(WithBypass(r.Context()))
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,148 @@
+// Package cache is the in-process read cache of the services. Services get the cache of their kind from
+// the Registry, read through it by id, and invalidate the objects they write, on every replica through a
+// postgres notification.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// Cache is the least recently used cache of the objects of kind T, by id. The methods of a nil Cache, that
+// of a kind not cached, go to the database every time.
+//
+// Objects stay cached until they're invalidated, evicted for newer ones, or found older than the ttl, in
+// case an invalidation was missed while a replica wasn't listening.
+type Cache[T any] struct {
+	kind     string
+	registry *Registry
+	size     int
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	// recent holds the entries, most recently used first
+	recent *list.List
+	// generation counts the invalidations, so objects read before one aren't cached after it
+	generation uint64
+}
+
+type entry[T any] struct {
+	id      string
+	object  T
+	expires time.Time
+}
+
+func newCache[T any](kind string, registry *Registry, size int, ttl time.Duration) *Cache[T] {
+	return &Cache[T]{
+		kind:     kind,
+		registry: registry,
+		size:     size,
+		ttl:      ttl,
+		entries:  map[string]*list.Element{},
+		recent:   list.New(),
+	}
+}
+
+// Get returns a copy of the object cached under id, or reads it with load and caches it. Errors aren't
+// cached. Reads of contexts bypassing the cache, see WithBypass, always load.
+func (c *Cache[T]) Get(ctx context.Context, id string, load func() (*T, *errors.ServiceError)) (*T, *errors.ServiceError) {
+	if c == nil {
+		return load()
+	}
+	if Bypassed(ctx) {
+		updateLookupMetrics(c.kind, resultBypassed)
+		return load()
+	}
+
+	object, generation, found := c.lookup(id)
+	if found {
+		updateLookupMetrics(c.kind, resultHit)
+		return object, nil
+	}
+	updateLookupMetrics(c.kind, resultMiss)
+
+	object, err := load()
+	if err != nil {
+		return nil, err
+	}
+	c.store(id, object, generation)
+	return object, nil
+}
+
+// Invalidate drops the object cached under id from the caches of every replica. Services call it with the
+// context of their writes: the replicas are notified once the transaction is committed, and the object
+// written can't be read before that.
+func (c *Cache[T]) Invalidate(ctx context.Context, id string) error {
+	if c == nil {
+		return nil
+	}
+	c.invalidate(id)
+	return c.registry.notify(ctx, c.kind, id)
+}
+
+func (c *Cache[T]) lookup(id string) (*T, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[id]
+	if !ok {
+		return nil, c.generation, false
+	}
+	e := element.Value.(*entry[T])
+	if c.registry.clock.Now().After(e.expires) {
+		c.remove(element, evictedExpired)
+		return nil, c.generation, false
+	}
+	c.recent.MoveToFront(element)
+	object := e.object
+	return &object, c.generation, true
+}
+
+// store caches a copy of object, unless the cache was invalidated since it was read at generation
+func (c *Cache[T]) store(id string, object *T, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation || c.size <= 0 {
+		return
+	}
+	e := &entry[T]{id: id, object: *object, expires: c.registry.clock.Now().Add(c.ttl)}
+	if element, ok := c.entries[id]; ok {
+		element.Value = e
+		c.recent.MoveToFront(element)
+		return
+	}
+	c.entries[id] = c.recent.PushFront(e)
+	for c.recent.Len() > c.size {
+		c.remove(c.recent.Back(), evictedCapacity)
+	}
+}
+
+// invalidate drops the object cached under id from this replica's cache
+func (c *Cache[T]) invalidate(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if element, ok := c.entries[id]; ok {
+		c.remove(element, evictedInvalidated)
+	}
+}
+
+func (c *Cache[T]) remove(element *list.Element, reason string) {
+	c.recent.Remove(element)
+	delete(c.entries, element.Value.(*entry[T]).id)
+	updateEvictionMetrics(c.kind, reason)
+}
+
+// Len is the number of objects cached
+func (c *Cache[T]) Len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.recent.Len()
+}
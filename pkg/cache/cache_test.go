@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// notifier delivers the notifications synchronously, to the listener of every replica
+type notifier struct {
+	mu        sync.Mutex
+	listeners []func(payload string)
+}
+
+func (n *notifier) NewListener(ctx context.Context, channel string, callback func(payload string)) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.listeners = append(n.listeners, callback)
+}
+
+func (n *notifier) Notify(ctx context.Context, channel, payload string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, listener := range n.listeners {
+		listener(payload)
+	}
+	return nil
+}
+
+func (n *notifier) listening() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.listeners)
+}
+
+// loader counts its reads of the dinosaurs
+type loader struct {
+	reads     int
+	dinosaurs map[string]*api.Dinosaur
+}
+
+func (l *loader) load(id string) func() (*api.Dinosaur, *errors.ServiceError) {
+	return func() (*api.Dinosaur, *errors.ServiceError) {
+		l.reads++
+		dinosaur, ok := l.dinosaurs[id]
+		if !ok {
+			return nil, errors.NotFound("Dinosaur with id='%s' not found", id)
+		}
+		found := *dinosaur
+		return &found, nil
+	}
+}
+
+func newRegistry(notifier Notifier, clock clock.Clock) *Registry {
+	return NewRegistry(&config.CacheConfig{Kinds: []string{"Dinosaur"}, Size: 2, TTL: time.Minute}, notifier, clock)
+}
+
+func TestCacheGet(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	now := clock.NewFake(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	registry := newRegistry(&notifier{}, now)
+	Expect(For[api.CustomResource](registry, "widget")).To(BeNil())
+	c := For[api.Dinosaur](registry, "Dinosaur")
+	Expect(For[api.Dinosaur](registry, "Dinosaur")).To(BeIdenticalTo(c))
+
+	l := &loader{dinosaurs: map[string]*api.Dinosaur{
+		"1": {Meta: api.Meta{ID: "1"}, Species: "Stegosaurus"},
+		"2": {Meta: api.Meta{ID: "2"}, Species: "Triceratops"},
+		"3": {Meta: api.Meta{ID: "3"}, Species: "Velociraptor"},
+	}}
+
+	dinosaur, err := c.Get(ctx, "1", l.load("1"))
+	Expect(err).To(BeNil())
+	Expect(dinosaur.Species).To(Equal("Stegosaurus"))
+	// the object cached can't be changed by the callers
+	dinosaur.Species = "Brontosaurus"
+	dinosaur, _ = c.Get(ctx, "1", l.load("1"))
+	Expect(dinosaur.Species).To(Equal("Stegosaurus"))
+	Expect(l.reads).To(Equal(1))
+
+	// errors aren't cached
+	_, err = c.Get(ctx, "4", l.load("4"))
+	Expect(err).NotTo(BeNil())
+	_, err = c.Get(ctx, "4", l.load("4"))
+	Expect(err.Code).To(Equal(errors.ErrorNotFound))
+	Expect(l.reads).To(Equal(3))
+
+	// 2 is the least recently used once 1 is read again, and is evicted for 3
+	c.Get(ctx, "2", l.load("2"))
+	c.Get(ctx, "1", l.load("1"))
+	c.Get(ctx, "3", l.load("3"))
+	Expect(c.Len()).To(Equal(2))
+	Expect(l.reads).To(Equal(5))
+	c.Get(ctx, "1", l.load("1"))
+	c.Get(ctx, "2", l.load("2"))
+	Expect(l.reads).To(Equal(6))
+
+	// the objects are read again once their ttl is over
+	now.Advance(2 * time.Minute)
+	c.Get(ctx, "2", l.load("2"))
+	Expect(l.reads).To(Equal(7))
+
+	// and every time bypassing the cache
+	c.Get(WithBypass(ctx), "2", l.load("2"))
+	Expect(l.reads).To(Equal(8))
+
+	// a kind not cached is read every time
+	var none *Cache[api.Dinosaur]
+	none.Get(ctx, "1", l.load("1"))
+	none.Get(ctx, "1", l.load("1"))
+	Expect(l.reads).To(Equal(10))
+	Expect(none.Invalidate(ctx, "1")).To(Succeed())
+}
+
+func TestCacheInvalidate(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	n := &notifier{}
+	replicas := []*Registry{newRegistry(n, clock.New()), newRegistry(n, clock.New())}
+	var caches []*Cache[api.Dinosaur]
+	for _, registry := range replicas {
+		go registry.Listen(ctx)
+		caches = append(caches, For[api.Dinosaur](registry, "Dinosaur"))
+	}
+	Eventually(n.listening).Should(Equal(2))
+
+	l := &loader{dinosaurs: map[string]*api.Dinosaur{"1": {Meta: api.Meta{ID: "1"}, Species: "Stegosaurus"}}}
+	for _, c := range caches {
+		c.Get(ctx, "1", l.load("1"))
+	}
+	Expect(l.reads).To(Equal(2))
+
+	// the write of a replica invalidates the object on every replica
+	l.dinosaurs["1"].Species = "Triceratops"
+	Expect(caches[0].Invalidate(ctx, "1")).To(Succeed())
+	for _, c := range caches {
+		Expect(c.Len()).To(Equal(0))
+		dinosaur, _ := c.Get(ctx, "1", l.load("1"))
+		Expect(dinosaur.Species).To(Equal("Triceratops"))
+	}
+	Expect(l.reads).To(Equal(4))
+
+	// an object read before an invalidation isn't cached after it
+	caches[1].Get(ctx, "2", func() (*api.Dinosaur, *errors.ServiceError) {
+		caches[0].Invalidate(ctx, "2")
+		return &api.Dinosaur{Meta: api.Meta{ID: "2"}}, nil
+	})
+	Expect(caches[1].Len()).To(Equal(1))
+}
+
+func TestBypassMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	var bypassed bool
+	handler := BypassMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bypassed = Bypassed(r.Context())
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/ocm-example-service/v1/dinosaurs/1", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	Expect(bypassed).To(BeFalse())
+
+	request.Header.Set(BypassHeader, "true")
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	Expect(bypassed).To(BeTrue())
+}
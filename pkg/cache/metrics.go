@@ -0,0 +1,69 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "cache"
+
+// Names of the labels added to metrics:
+const (
+	metricsKindLabel   = "kind"
+	metricsResultLabel = "result"
+	metricsReasonLabel = "reason"
+)
+
+// Results of the lookups:
+const (
+	resultHit  = "hit"
+	resultMiss = "miss"
+	// the request bypassed the cache, see BypassHeader
+	resultBypassed = "bypassed"
+)
+
+// Reasons of the evictions:
+const (
+	evictedCapacity    = "capacity"
+	evictedExpired     = "expired"
+	evictedInvalidated = "invalidated"
+)
+
+// Description of the lookups metric:
+var lookupsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "lookups",
+		Help:      "Number of reads by id through the cache, by their result.",
+	},
+	[]string{metricsKindLabel, metricsResultLabel},
+)
+
+// Description of the evictions metric:
+var evictionsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "evictions",
+		Help:      "Number of objects dropped from the cache, by the reason they were.",
+	},
+	[]string{metricsKindLabel, metricsReasonLabel},
+)
+
+func init() {
+	prometheus.MustRegister(lookupsMetric)
+	prometheus.MustRegister(evictionsMetric)
+}
+
+func updateLookupMetrics(kind, result string) {
+	lookupsMetric.With(prometheus.Labels{
+		metricsKindLabel:   kind,
+		metricsResultLabel: result,
+	}).Inc()
+}
+
+func updateEvictionMetrics(kind, reason string) {
+	evictionsMetric.With(prometheus.Labels{
+		metricsKindLabel:   kind,
+		metricsReasonLabel: reason,
+	}).Inc()
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// Channel is the channel the invalidations are notified on, with payloads of the form kind/id
+const Channel = "cache"
+
+// Notifier sends and listens for notifications, as the database session factories do with pg_notify
+type Notifier interface {
+	NewListener(ctx context.Context, channel string, callback func(payload string))
+	Notify(ctx context.Context, channel, payload string) error
+}
+
+// Registry holds the caches of the kinds configured, and invalidates them with the notifications of the
+// other replicas, see Listen
+type Registry struct {
+	config   *config.CacheConfig
+	notifier Notifier
+	clock    clock.Clock
+
+	mu     sync.Mutex
+	caches map[string]invalidator
+}
+
+type invalidator interface {
+	invalidate(id string)
+}
+
+func NewRegistry(config *config.CacheConfig, notifier Notifier, clock clock.Clock) *Registry {
+	return &Registry{
+		config:   config,
+		notifier: notifier,
+		clock:    clock,
+		caches:   map[string]invalidator{},
+	}
+}
+
+// For returns the cache of kind, the same every call, or nil when kind isn't configured to be cached
+func For[T any](r *Registry, kind string) *Cache[T] {
+	if r == nil || !r.enabled(kind) {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.caches[kind]; ok {
+		return c.(*Cache[T])
+	}
+	c := newCache[T](kind, r, r.config.Size, r.config.TTL)
+	r.caches[kind] = c
+	return c
+}
+
+func (r *Registry) enabled(kind string) bool {
+	for _, k := range r.config.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Listen is a blocking call invalidating the caches with the notifications of the writes of every
+// replica, this one included, until ctx is done
+func (r *Registry) Listen(ctx context.Context) {
+	if len(r.config.Kinds) == 0 {
+		return
+	}
+	logger.NewOCMLogger(ctx).Infof("Caching %s, invalidated on channel %s", strings.Join(r.config.Kinds, ", "), Channel)
+	r.notifier.NewListener(ctx, Channel, r.handle)
+}
+
+func (r *Registry) notify(ctx context.Context, kind, id string) error {
+	if err := r.notifier.Notify(ctx, Channel, kind+"/"+id); err != nil {
+		return fmt.Errorf("unable to notify the invalidation of %s %s: %s", kind, id, err)
+	}
+	return nil
+}
+
+func (r *Registry) handle(payload string) {
+	kind, id, ok := strings.Cut(payload, "/")
+	if !ok {
+		logger.NewOCMLogger(context.Background()).Warning(fmt.Sprintf("Ignoring invalid cache invalidation '%s'", payload))
+		return
+	}
+	r.mu.Lock()
+	c, ok := r.caches[kind]
+	r.mu.Unlock()
+	if ok {
+		c.invalidate(id)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	sdkClient "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift-online/rh-trex/pkg/logger"
 )
 
 type Client struct {
@@ -56,7 +58,9 @@ func (c *Client) newConnection() error {
 	builder := sdkClient.NewConnectionBuilder().
 		Logger(c.logger).
 		URL(c.config.BaseURL).
-		MetricsSubsystem("api_outbound")
+		MetricsSubsystem("api_outbound").
+		// the services called log the operation ID of the request calling them as their parent
+		TransportWrapper(logger.OperationIDTransport)
 
 	if c.config.ClientID != "" && c.config.ClientSecret != "" {
 		builder = builder.Client(c.config.ClientID, c.config.ClientSecret)
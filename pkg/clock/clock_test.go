@@ -0,0 +1,29 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFake(t *testing.T) {
+	RegisterTestingT(t)
+
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	Expect(fake.Now()).To(Equal(start))
+	Expect(fake.Now()).To(Equal(start))
+
+	Expect(fake.Advance(time.Hour)).To(Equal(start.Add(time.Hour)))
+	Expect(fake.Now()).To(Equal(start.Add(time.Hour)))
+
+	fake.Set(start.Add(-time.Minute))
+	Expect(fake.Now()).To(Equal(start.Add(-time.Minute)))
+}
+
+func TestNew(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(New().Now()).To(BeTemporally("~", time.Now(), time.Second))
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+)
+
+type AdmissionConfig struct {
+	ConfigFile string `json:"config_file"`
+	// Hooks are read from ConfigFile, and called in order
+	Hooks []AdmissionHookConfig `json:"hooks"`
+}
+
+// AdmissionHookConfig registers a webhook, or an in-process hook, for some kinds and operations
+type AdmissionHookConfig struct {
+	Name string `json:"name"`
+	// URL is that of a webhook, Hook the name of an in-process hook; one of them is set
+	URL  string `json:"url"`
+	Hook string `json:"hook"`
+	// Kinds and Operations, any of CREATE, UPDATE and DELETE, are those the hook is called for, all of
+	// them when empty
+	Kinds          []string `json:"kinds"`
+	Operations     []string `json:"operations"`
+	TimeoutSeconds int      `json:"timeout_seconds"`
+	// FailurePolicy is Fail, the default, or Ignore
+	FailurePolicy string `json:"failure_policy"`
+}
+
+func NewAdmissionConfig() *AdmissionConfig {
+	return &AdmissionConfig{
+		ConfigFile: "",
+	}
+}
+
+func (c *AdmissionConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.ConfigFile, "admission-config-file", c.ConfigFile, "YAML file listing the admission hooks called before writes, none by default")
+}
+
+func (c *AdmissionConfig) ReadFiles() error {
+	content, err := readFile(c.ConfigFile)
+	if err != nil || content == "" {
+		return err
+	}
+	return yaml.Unmarshal([]byte(content), c)
+}
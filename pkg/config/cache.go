@@ -0,0 +1,32 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// CacheConfig configures the in-process read cache of the services, which is off for the kinds not listed
+type CacheConfig struct {
+	Kinds []string      `json:"kinds"`
+	Size  int           `json:"size"`
+	TTL   time.Duration `json:"ttl"`
+}
+
+func NewCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		Kinds: []string{},
+		Size:  1000,
+		TTL:   30 * time.Second,
+	}
+}
+
+func (c *CacheConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&c.Kinds, "cache-kinds", c.Kinds, "Kinds whose objects are cached by id, e.g. Dinosaur")
+	fs.IntVar(&c.Size, "cache-size", c.Size, "Maximum number of objects cached per kind, the least recently used being evicted")
+	fs.DurationVar(&c.TTL, "cache-ttl", c.TTL, "How long objects stay cached at most, in case an invalidation is missed")
+}
+
+func (c *CacheConfig) ReadFiles() error {
+	return nil
+}
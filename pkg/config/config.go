@@ -19,6 +19,10 @@ type ApplicationConfig struct {
 	Database    *DatabaseConfig    `json:"database"`
 	OCM         *OCMConfig         `json:"ocm"`
 	Sentry      *SentryConfig      `json:"sentry"`
+	Outbox      *OutboxConfig      `json:"outbox"`
+	GRPC        *GRPCConfig        `json:"grpc"`
+	Admission   *AdmissionConfig   `json:"admission"`
+	Cache       *CacheConfig       `json:"cache"`
 }
 
 func NewApplicationConfig() *ApplicationConfig {
@@ -29,6 +33,10 @@ func NewApplicationConfig() *ApplicationConfig {
 		Database:    NewDatabaseConfig(),
 		OCM:         NewOCMConfig(),
 		Sentry:      NewSentryConfig(),
+		Outbox:      NewOutboxConfig(),
+		GRPC:        NewGRPCConfig(),
+		Admission:   NewAdmissionConfig(),
+		Cache:       NewCacheConfig(),
 	}
 }
 
@@ -40,6 +48,10 @@ func (c *ApplicationConfig) AddFlags(flagset *pflag.FlagSet) {
 	c.Database.AddFlags(flagset)
 	c.OCM.AddFlags(flagset)
 	c.Sentry.AddFlags(flagset)
+	c.Outbox.AddFlags(flagset)
+	c.GRPC.AddFlags(flagset)
+	c.Admission.AddFlags(flagset)
+	c.Cache.AddFlags(flagset)
 }
 
 func (c *ApplicationConfig) ReadFiles() []string {
@@ -53,6 +65,10 @@ func (c *ApplicationConfig) ReadFiles() []string {
 		{c.Metrics.ReadFiles, "Metrics"},
 		{c.HealthCheck.ReadFiles, "HealthCheck"},
 		{c.Sentry.ReadFiles, "Sentry"},
+		{c.Outbox.ReadFiles, "Outbox"},
+		{c.GRPC.ReadFiles, "GRPC"},
+		{c.Admission.ReadFiles, "Admission"},
+		{c.Cache.ReadFiles, "Cache"},
 	}
 	messages := []string{}
 	for _, rf := range readFiles {
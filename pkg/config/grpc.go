@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// GRPCConfig configures the gRPC API. It authenticates calls and uses TLS as the server config has the
+// REST API do.
+type GRPCConfig struct {
+	Enabled     bool   `json:"enabled"`
+	BindAddress string `json:"bind_address"`
+}
+
+func NewGRPCConfig() *GRPCConfig {
+	return &GRPCConfig{
+		Enabled:     true,
+		BindAddress: "localhost:9000",
+	}
+}
+
+func (s *GRPCConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&s.Enabled, "enable-grpc", s.Enabled, "Serve the gRPC API")
+	fs.StringVar(&s.BindAddress, "grpc-server-bindaddress", s.BindAddress, "gRPC server bind adddress")
+}
+
+func (s *GRPCConfig) ReadFiles() error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type OutboxConfig struct {
+	Publishers   []string      `json:"publishers"`
+	PollInterval time.Duration `json:"poll_interval"`
+	BatchSize    int           `json:"batch_size"`
+	SettleTime   time.Duration `json:"settle_time"`
+	File         string        `json:"file"`
+	KafkaBrokers []string      `json:"kafka_brokers"`
+	KafkaTopic   string        `json:"kafka_topic"`
+	KafkaTimeout time.Duration `json:"kafka_timeout"`
+}
+
+func NewOutboxConfig() *OutboxConfig {
+	return &OutboxConfig{
+		Publishers:   []string{},
+		PollInterval: 1 * time.Second,
+		BatchSize:    100,
+		SettleTime:   5 * time.Second,
+		File:         "-",
+		KafkaBrokers: []string{"localhost:9092"},
+		KafkaTopic:   "ocm-example-service-events",
+		KafkaTimeout: 10 * time.Second,
+	}
+}
+
+func (c *OutboxConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&c.Publishers, "outbox-publishers", c.Publishers, "Publishers to relay events to, any of kafka and file")
+	fs.DurationVar(&c.PollInterval, "outbox-poll-interval", c.PollInterval, "How often the outbox is polled for new events")
+	fs.IntVar(&c.BatchSize, "outbox-batch-size", c.BatchSize, "Maximum number of events published at once")
+	fs.DurationVar(&c.SettleTime, "outbox-settle-time", c.SettleTime, "How old events must be before they are published, so those of transactions committing late aren't skipped")
+	fs.StringVar(&c.File, "outbox-file", c.File, "File the file publisher appends events to, - for stdout")
+	fs.StringSliceVar(&c.KafkaBrokers, "outbox-kafka-brokers", c.KafkaBrokers, "Kafka brokers the kafka publisher bootstraps from")
+	fs.StringVar(&c.KafkaTopic, "outbox-kafka-topic", c.KafkaTopic, "Kafka topic the kafka publisher produces events to")
+	fs.DurationVar(&c.KafkaTimeout, "outbox-kafka-timeout", c.KafkaTimeout, "Timeout for the requests made to Kafka brokers")
+}
+
+func (c *OutboxConfig) ReadFiles() error {
+	return nil
+}
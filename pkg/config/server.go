@@ -19,6 +19,9 @@ type ServerConfig struct {
 	JwkCertFile   string        `json:"jwk_cert_file"`
 	JwkCertURL    string        `json:"jwk_cert_url"`
 	ACLFile       string        `json:"acl_file"`
+	EnableGraphQL bool          `json:"enable_graphql"`
+	// AdminUsers are the usernames allowed to define and delete custom kinds
+	AdminUsers []string `json:"admin_users"`
 }
 
 func NewServerConfig() *ServerConfig {
@@ -35,6 +38,7 @@ func NewServerConfig() *ServerConfig {
 		ACLFile:       "",
 		HTTPSCertFile: "",
 		HTTPSKeyFile:  "",
+		EnableGraphQL: false,
 	}
 }
 
@@ -51,6 +55,8 @@ func (s *ServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.JwkCertFile, "jwk-cert-file", s.JwkCertFile, "JWK Certificate file")
 	fs.StringVar(&s.JwkCertURL, "jwk-cert-url", s.JwkCertURL, "JWK Certificate URL")
 	fs.StringVar(&s.ACLFile, "acl-file", s.ACLFile, "Access control list file")
+	fs.BoolVar(&s.EnableGraphQL, "enable-graphql", s.EnableGraphQL, "Serve the read only GraphQL API at /graphql")
+	fs.StringSliceVar(&s.AdminUsers, "admin-users", s.AdminUsers, "Usernames allowed to define and delete custom kinds, none by default")
 }
 
 func (s *ServerConfig) ReadFiles() error {
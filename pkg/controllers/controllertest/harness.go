@@ -0,0 +1,258 @@
+// Package controllertest drives a KindControllerManager synchronously, so controllers can be tested
+// without the pg_notify listener and without sleeping.
+package controllertest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/controllers"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+// maxHandled bounds a single ReconcileAll, so handlers creating events without end fail the test instead
+// of hanging it
+const maxHandled = 10000
+
+// Harness captures the events created through its event service, and hands them to its controller
+// manager when the test calls ReconcileAll.
+//
+//	h := controllertest.NewHarness(t, services.NewEventService(dao.NewEventDao(&dbFactory)))
+//	dinosaurs := services.NewDinosaurService(locks, &dbFactory, dao.NewDinosaurDao(&dbFactory), h.Events(), admission.NewChain(), services.NewHooks(), nil)
+//	h.Add(&controllers.ControllerConfig{Source: "Dinosaurs", Handlers: ...})
+//
+//	dino, _ := dinosaurs.Create(ctx, &api.Dinosaur{Species: "Stegosaurus"})
+//	h.ExpectEvent("Dinosaurs", dino.ID, api.CreateEventType)
+//	h.ReconcileAll()
+type Harness struct {
+	T       testing.TB
+	Manager *controllers.KindControllerManager
+	// Clock stamps the ReconciledDate of the events, it only moves when the test says so
+	Clock *clock.Fake
+
+	events *capturingEventService
+
+	mu       sync.Mutex
+	failures map[failureKey][]error
+	injected map[string]error
+}
+
+type failureKey struct {
+	source    string
+	eventType api.EventType
+}
+
+func NewHarness(t testing.TB, events services.EventService) *Harness {
+	capturing := &capturingEventService{
+		EventService: events,
+		reconciled:   map[string]bool{},
+	}
+	fakeClock := clock.NewFake(time.Now())
+	return &Harness{
+		T:        t,
+		Manager:  controllers.NewKindControllerManager(capturing, fakeClock),
+		Clock:    fakeClock,
+		events:   capturing,
+		failures: map[failureKey][]error{},
+		injected: map[string]error{},
+	}
+}
+
+// Events is the event service the services under test must create their events with
+func (h *Harness) Events() services.EventService {
+	return h.events
+}
+
+// Add registers a controller with the manager, its handlers wrapped so FailNext can fail them
+func (h *Harness) Add(config *controllers.ControllerConfig) {
+	wrapped := &controllers.ControllerConfig{
+		Source:   config.Source,
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{},
+	}
+	for eventType, fns := range config.Handlers {
+		for _, fn := range fns {
+			wrapped.Handlers[eventType] = append(wrapped.Handlers[eventType], h.failable(fn))
+		}
+	}
+	h.Manager.Add(wrapped)
+}
+
+// FailNext makes the next handling of a source's event of the given type fail with err, before any of
+// its handlers run. The event stays pending, as it would in the service, until a later ReconcileAll.
+// Calling FailNext again fails as many handlings more.
+func (h *Harness) FailNext(source string, eventType api.EventType, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := failureKey{source: source, eventType: eventType}
+	h.failures[key] = append(h.failures[key], err)
+}
+
+func (h *Harness) failable(fn controllers.ControllerHandlerFunc) controllers.ControllerHandlerFunc {
+	return func(ctx context.Context, id string) error {
+		eventID, _ := ctx.Value("event").(string)
+		h.mu.Lock()
+		err, found := h.injected[eventID]
+		delete(h.injected, eventID)
+		h.mu.Unlock()
+		if found {
+			return err
+		}
+		return fn(ctx, id)
+	}
+}
+
+// inject pops the failure due for event, for its first handler to return
+func (h *Harness) inject(event *api.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := failureKey{source: event.Source, eventType: event.EventType}
+	if len(h.failures[key]) == 0 {
+		return
+	}
+	h.injected[event.ID] = h.failures[key][0]
+	h.failures[key] = h.failures[key][1:]
+}
+
+// ReconcileAll handles every pending event once, in the order they were created, including the events
+// created by the handlers along the way. Events whose handlers fail stay pending. It returns the number
+// of events reconciled.
+func (h *Harness) ReconcileAll() int {
+	h.T.Helper()
+	ctx := context.Background()
+
+	queue := h.events.pending()
+	queued := map[string]bool{}
+	for _, id := range queue {
+		queued[id] = true
+	}
+
+	reconciled := 0
+	for handled := 0; len(queue) > 0; handled++ {
+		if handled == maxHandled {
+			h.T.Fatalf("Gave up reconciling after handling %d events, the handlers keep creating events", handled)
+			return reconciled
+		}
+		id := queue[0]
+		queue = queue[1:]
+
+		event, err := h.events.Get(ctx, id)
+		if err != nil {
+			h.T.Fatalf("Unable to get event %s: %s", id, err)
+			return reconciled
+		}
+		h.inject(event)
+		h.Manager.Handle(id)
+		h.mu.Lock()
+		delete(h.injected, id)
+		h.mu.Unlock()
+		if h.events.isReconciled(id) {
+			reconciled++
+		}
+
+		// handle the events created by the handlers after those already queued
+		for _, created := range h.events.pending() {
+			if !queued[created] {
+				queued[created] = true
+				queue = append(queue, created)
+			}
+		}
+	}
+	return reconciled
+}
+
+// Pending returns the captured events not reconciled yet, in the order they were created
+func (h *Harness) Pending() api.EventList {
+	h.T.Helper()
+	list := api.EventList{}
+	for _, id := range h.events.pending() {
+		event, err := h.events.Get(context.Background(), id)
+		if err != nil {
+			h.T.Fatalf("Unable to get event %s: %s", id, err)
+			return list
+		}
+		list = append(list, event)
+	}
+	return list
+}
+
+// ExpectEvent fails the test unless an event was created for the source and id with the given type. It
+// returns the latest such event, or nil.
+func (h *Harness) ExpectEvent(source, sourceID string, eventType api.EventType) *api.Event {
+	h.T.Helper()
+	var found *api.Event
+	for _, id := range h.events.all() {
+		event, err := h.events.Get(context.Background(), id)
+		if err != nil {
+			h.T.Fatalf("Unable to get event %s: %s", id, err)
+			return nil
+		}
+		if event.Source == source && event.SourceID == sourceID && event.EventType == eventType {
+			found = event
+		}
+	}
+	if found == nil {
+		h.T.Errorf("Expected a %s event for %s %s", eventType, source, sourceID)
+	}
+	return found
+}
+
+// capturingEventService records the events created through it, and those reconciled by the manager
+type capturingEventService struct {
+	services.EventService
+
+	mu         sync.Mutex
+	created    []string
+	reconciled map[string]bool
+}
+
+var _ services.EventService = &capturingEventService{}
+
+func (s *capturingEventService) Create(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError) {
+	event, err := s.EventService.Create(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created = append(s.created, event.ID)
+	return event, nil
+}
+
+// Replace is only called by the manager once all the handlers of an event succeeded
+func (s *capturingEventService) Replace(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError) {
+	if event.ReconciledDate != nil {
+		s.mu.Lock()
+		s.reconciled[event.ID] = true
+		s.mu.Unlock()
+	}
+	return s.EventService.Replace(ctx, event)
+}
+
+func (s *capturingEventService) all() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.created...)
+}
+
+func (s *capturingEventService) pending() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending := []string{}
+	for _, id := range s.created {
+		if !s.reconciled[id] {
+			pending = append(pending, id)
+		}
+	}
+	return pending
+}
+
+func (s *capturingEventService) isReconciled(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reconciled[id]
+}
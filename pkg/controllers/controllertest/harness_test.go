@@ -0,0 +1,139 @@
+package controllertest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/controllers"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/db/db_session"
+	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+func newTestHarness(t *testing.T) (*Harness, services.DinosaurService) {
+	var dbFactory db.SessionFactory = db_session.NewMemoryFactory(config.NewDatabaseConfig())
+	t.Cleanup(func() { _ = dbFactory.Close() })
+
+	h := NewHarness(t, services.NewEventService(dao.NewEventDao(&dbFactory)))
+	dinosaurs := services.NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), &dbFactory, dao.NewDinosaurDao(&dbFactory), h.Events(), admission.NewChain(), services.NewHooks(), nil)
+	return h, dinosaurs
+}
+
+func TestHarnessReconcileAll(t *testing.T) {
+	RegisterTestingT(t)
+	h, dinosaurs := newTestHarness(t)
+	ctx := context.Background()
+
+	upserted := []string{}
+	h.Add(&controllers.ControllerConfig{
+		Source: "Dinosaurs",
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error {
+				upserted = append(upserted, id)
+				return nil
+			}},
+		},
+	})
+
+	first, serviceErr := dinosaurs.Create(ctx, &api.Dinosaur{Species: "Stegosaurus"})
+	Expect(serviceErr).NotTo(HaveOccurred())
+	second, serviceErr := dinosaurs.Create(ctx, &api.Dinosaur{Species: "Triceratops"})
+	Expect(serviceErr).NotTo(HaveOccurred())
+
+	event := h.ExpectEvent("Dinosaurs", first.ID, api.CreateEventType)
+	Expect(event).NotTo(BeNil())
+	Expect(event.ReconciledDate).To(BeNil())
+	Expect(upserted).To(BeEmpty())
+
+	Expect(h.ReconcileAll()).To(Equal(2))
+	Expect(upserted).To(Equal([]string{first.ID, second.ID}))
+	Expect(h.Pending()).To(BeEmpty())
+	reconciledDate := h.ExpectEvent("Dinosaurs", first.ID, api.CreateEventType).ReconciledDate
+	Expect(reconciledDate).NotTo(BeNil())
+	Expect(*reconciledDate).To(BeTemporally("==", h.Clock.Now()))
+
+	// nothing left to do
+	Expect(h.ReconcileAll()).To(Equal(0))
+	Expect(upserted).To(HaveLen(2))
+}
+
+func TestHarnessFailNext(t *testing.T) {
+	RegisterTestingT(t)
+	h, dinosaurs := newTestHarness(t)
+	ctx := context.Background()
+
+	calls := 0
+	h.Add(&controllers.ControllerConfig{
+		Source: "Dinosaurs",
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error {
+				calls++
+				return nil
+			}},
+		},
+	})
+
+	dino, serviceErr := dinosaurs.Create(ctx, &api.Dinosaur{Species: "Stegosaurus"})
+	Expect(serviceErr).NotTo(HaveOccurred())
+
+	h.FailNext("Dinosaurs", api.CreateEventType, fmt.Errorf("connection refused"))
+	Expect(h.ReconcileAll()).To(Equal(0))
+	Expect(calls).To(Equal(0))
+	Expect(h.Pending()).To(HaveLen(1))
+	Expect(h.Pending()[0].SourceID).To(Equal(dino.ID))
+
+	// the failed event is retried by the next reconciliation
+	Expect(h.ReconcileAll()).To(Equal(1))
+	Expect(calls).To(Equal(1))
+	Expect(h.Pending()).To(BeEmpty())
+}
+
+func TestHarnessFollowUpEvents(t *testing.T) {
+	RegisterTestingT(t)
+	h, dinosaurs := newTestHarness(t)
+	ctx := context.Background()
+
+	// a controller reacting to a dinosaur by emitting an event of its own
+	notified := []string{}
+	h.Add(&controllers.ControllerConfig{
+		Source: "Dinosaurs",
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error {
+				_, err := h.Events().Create(ctx, &api.Event{
+					Source:    "Notifications",
+					SourceID:  id,
+					EventType: api.CreateEventType,
+				})
+				if err != nil {
+					return err
+				}
+				return nil
+			}},
+		},
+	})
+	h.Add(&controllers.ControllerConfig{
+		Source: "Notifications",
+		Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error {
+				notified = append(notified, id)
+				return nil
+			}},
+		},
+	})
+
+	dino, serviceErr := dinosaurs.Create(ctx, &api.Dinosaur{Species: "Stegosaurus"})
+	Expect(serviceErr).NotTo(HaveOccurred())
+
+	Expect(h.ReconcileAll()).To(Equal(2))
+	Expect(notified).To(Equal([]string{dino.ID}))
+	Expect(h.ExpectEvent("Notifications", dino.ID, api.CreateEventType)).NotTo(BeNil())
+	Expect(h.Pending()).To(BeEmpty())
+}
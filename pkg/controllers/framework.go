@@ -3,9 +3,9 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/logger"
 	"github.com/openshift-online/rh-trex/pkg/services"
 )
@@ -40,12 +40,14 @@ type ControllerConfig struct {
 type KindControllerManager struct {
 	controllers map[string]map[api.EventType][]ControllerHandlerFunc
 	events      services.EventService
+	clock       clock.Clock
 }
 
-func NewKindControllerManager(events services.EventService) *KindControllerManager {
+func NewKindControllerManager(events services.EventService, clock clock.Clock) *KindControllerManager {
 	return &KindControllerManager{
 		controllers: map[string]map[api.EventType][]ControllerHandlerFunc{},
 		events:      events,
+		clock:       clock,
 	}
 }
 
@@ -117,7 +119,7 @@ func (km *KindControllerManager) handle(ctx context.Context, id string) {
 	}
 
 	// all handlers successfully executed
-	now := time.Now()
+	now := km.clock.Now()
 	event.ReconciledDate = &now
 	_, err = km.events.Replace(ctx, event)
 	if err != nil {
@@ -3,9 +3,11 @@ package controllers
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/dao/mocks"
 	"github.com/openshift-online/rh-trex/pkg/services"
 )
@@ -48,7 +50,8 @@ func TestControllerFramework(t *testing.T) {
 	ctx := context.Background()
 	eventsDao := mocks.NewEventDao()
 	events := services.NewEventService(eventsDao)
-	mgr := NewKindControllerManager(events)
+	fakeClock := clock.NewFake(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC))
+	mgr := NewKindControllerManager(events, fakeClock)
 
 	ctrl := &exampleController{}
 	config := newExampleControllerConfig(ctrl)
@@ -85,4 +88,5 @@ func TestControllerFramework(t *testing.T) {
 
 	eve, _ := eventsDao.Get(ctx, "1")
 	Expect(eve.ReconciledDate).ToNot(BeNil(), "event reconcile date should be set")
+	Expect(*eve.ReconciledDate).To(Equal(fakeClock.Now()), "event reconcile date should come from the clock")
 }
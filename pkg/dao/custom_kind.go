@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/db"
+)
+
+//go:generate mockgen -source=custom_kind.go -destination=mocks/mock_custom_kind.go -package=mocks
+type CustomKindDao interface {
+	Get(ctx context.Context, name string) (*api.CustomKind, error)
+	Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, error)
+	Delete(ctx context.Context, id string) error
+	All(ctx context.Context) (api.CustomKindList, error)
+}
+
+var _ CustomKindDao = &sqlCustomKindDao{}
+
+type sqlCustomKindDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewCustomKindDao(sessionFactory *db.SessionFactory) CustomKindDao {
+	return &sqlCustomKindDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlCustomKindDao) Get(ctx context.Context, name string) (*api.CustomKind, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var kind api.CustomKind
+	if err := g2.Take(&kind, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &kind, nil
+}
+
+func (d *sqlCustomKindDao) Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(kind).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return kind, nil
+}
+
+func (d *sqlCustomKindDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Delete(&api.CustomKind{Meta: api.Meta{ID: id}}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlCustomKindDao) All(ctx context.Context) (api.CustomKindList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	kinds := api.CustomKindList{}
+	if err := g2.Find(&kinds).Error; err != nil {
+		return nil, err
+	}
+	return kinds, nil
+}
@@ -0,0 +1,74 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/db"
+)
+
+//go:generate mockgen -source=custom_resource.go -destination=mocks/mock_custom_resource.go -package=mocks
+type CustomResourceDao interface {
+	Get(ctx context.Context, id string) (*api.CustomResource, error)
+	Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error)
+	Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error)
+	Delete(ctx context.Context, id string) error
+	CountByKind(ctx context.Context, kind string) (int64, error)
+}
+
+var _ CustomResourceDao = &sqlCustomResourceDao{}
+
+type sqlCustomResourceDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewCustomResourceDao(sessionFactory *db.SessionFactory) CustomResourceDao {
+	return &sqlCustomResourceDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlCustomResourceDao) Get(ctx context.Context, id string) (*api.CustomResource, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var resource api.CustomResource
+	if err := g2.Take(&resource, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func (d *sqlCustomResourceDao) Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(resource).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (d *sqlCustomResourceDao) Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Save(resource).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return resource, nil
+}
+
+func (d *sqlCustomResourceDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Delete(&api.CustomResource{Meta: api.Meta{ID: id}}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlCustomResourceDao) CountByKind(ctx context.Context, kind string) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var count int64
+	if err := g2.Model(&api.CustomResource{}).Where("kind = ?", kind).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift-online/rh-trex/pkg/db"
 )
 
+//go:generate mockgen -source=dinosaur.go -destination=mocks/mock_dinosaur.go -package=mocks
 type DinosaurDao interface {
 	Get(ctx context.Context, id string) (*api.Dinosaur, error)
 	Create(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, error)
@@ -2,7 +2,6 @@ package dao
 
 import (
 	"context"
-	"fmt"
 
 	"gorm.io/gorm/clause"
 
@@ -10,6 +9,7 @@ import (
 	"github.com/openshift-online/rh-trex/pkg/db"
 )
 
+//go:generate mockgen -source=event.go -destination=mocks/mock_event.go -package=mocks
 type EventDao interface {
 	Get(ctx context.Context, id string) (*api.Event, error)
 	Create(ctx context.Context, event *api.Event) (*api.Event, error)
@@ -45,10 +45,7 @@ func (d *sqlEventDao) Create(ctx context.Context, event *api.Event) (*api.Event,
 		return nil, err
 	}
 
-	notify := fmt.Sprintf("select pg_notify('%s', '%s')", "events", event.ID)
-
-	err := g2.Exec(notify).Error
-	if err != nil {
+	if err := (*d.sessionFactory).Notify(ctx, "events", event.ID); err != nil {
 		return nil, err
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/openshift-online/rh-trex/pkg/db"
 )
 
+//go:generate mockgen -source=generic.go -destination=mocks/mock_generic.go -package=mocks
 type GenericDao interface {
 	Fetch(offset int, limit int, resourceList interface{}) error
 
@@ -35,7 +36,9 @@ type sqlGenericDao struct {
 
 // represents a relationship between two tables. They can be joined,
 // ON TableName.ColumnName = ForeignTableName.ForeignColumnName
+// Name is the association field on the api model, e.g. Dinosaur or Eggs, as expected by gorm's Preload
 type TableRelation struct {
+	Name              string
 	TableName         string
 	ColumnName        string
 	ForeignTableName  string
@@ -78,30 +81,17 @@ func (d *sqlGenericDao) Where(sql string, values []interface{}) {
 }
 
 func (d *sqlGenericDao) Count(model interface{}, total *int64) {
-	g2 := d.g2.Session(&gorm.Session{DryRun: false}).Model(model)
+	// the count is made on a copy of the query, with its conditions so it counts what Fetch lists
+	g2 := d.g2.Session(&gorm.Session{WithConditions: true}).Model(model)
 	// There is no need in ORDER BY, GROUP BY and LIMIT in order to count records
-	order, oko := g2.Statement.Clauses["ORDER BY"]
-	if oko {
-		delete(g2.Statement.Clauses, "ORDER BY")
-	}
-	group, okg := g2.Statement.Clauses["GROUP BY"]
-	if okg {
+	delete(g2.Statement.Clauses, "ORDER BY")
+	delete(g2.Statement.Clauses, "LIMIT")
+	if _, ok := g2.Statement.Clauses["GROUP BY"]; ok {
+		// the rows of the tables joined for a search are grouped by resource, count the resources instead
 		delete(g2.Statement.Clauses, "GROUP BY")
-	}
-	limit, okl := g2.Statement.Clauses["LIMIT"]
-	if okl {
-		delete(g2.Statement.Clauses, "LIMIT")
+		g2 = g2.Distinct(d.GetTableName() + ".id")
 	}
 	g2.Count(total)
-	if oko {
-		g2.Statement.Clauses["ORDER BY"] = order
-	}
-	if okg {
-		g2.Statement.Clauses["GROUP BY"] = group
-	}
-	if okl {
-		g2.Statement.Clauses["LIMIT"] = limit
-	}
 }
 
 // Gorm finishers (Take, First, Last, etc.) are not idempotent
@@ -146,6 +136,7 @@ func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool)
 	}
 
 	return TableRelation{
+		Name:              table,
 		TableName:         association.Relationship.Field.Schema.Table,
 		ForeignTableName:  association.Relationship.FieldSchema.Table,
 		ForeignColumnName: foreignColumnName,
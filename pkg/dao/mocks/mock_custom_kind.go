@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: custom_kind.go
+//
+// Generated by this command:
+//
+//	mockgen -source=custom_kind.go -destination=mocks/mock_custom_kind.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomKindDao is a mock of CustomKindDao interface.
+type MockCustomKindDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomKindDaoMockRecorder
+}
+
+// MockCustomKindDaoMockRecorder is the mock recorder for MockCustomKindDao.
+type MockCustomKindDaoMockRecorder struct {
+	mock *MockCustomKindDao
+}
+
+// NewMockCustomKindDao creates a new mock instance.
+func NewMockCustomKindDao(ctrl *gomock.Controller) *MockCustomKindDao {
+	mock := &MockCustomKindDao{ctrl: ctrl}
+	mock.recorder = &MockCustomKindDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomKindDao) EXPECT() *MockCustomKindDaoMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockCustomKindDao) All(ctx context.Context) (api.CustomKindList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx)
+	ret0, _ := ret[0].(api.CustomKindList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// All indicates an expected call of All.
+func (mr *MockCustomKindDaoMockRecorder) All(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockCustomKindDao)(nil).All), ctx)
+}
+
+// Create mocks base method.
+func (m *MockCustomKindDao) Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, kind)
+	ret0, _ := ret[0].(*api.CustomKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomKindDaoMockRecorder) Create(ctx, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomKindDao)(nil).Create), ctx, kind)
+}
+
+// Delete mocks base method.
+func (m *MockCustomKindDao) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomKindDaoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomKindDao)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCustomKindDao) Get(ctx context.Context, name string) (*api.CustomKind, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(*api.CustomKind)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCustomKindDaoMockRecorder) Get(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCustomKindDao)(nil).Get), ctx, name)
+}
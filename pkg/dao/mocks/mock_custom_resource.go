@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: custom_resource.go
+//
+// Generated by this command:
+//
+//	mockgen -source=custom_resource.go -destination=mocks/mock_custom_resource.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomResourceDao is a mock of CustomResourceDao interface.
+type MockCustomResourceDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomResourceDaoMockRecorder
+}
+
+// MockCustomResourceDaoMockRecorder is the mock recorder for MockCustomResourceDao.
+type MockCustomResourceDaoMockRecorder struct {
+	mock *MockCustomResourceDao
+}
+
+// NewMockCustomResourceDao creates a new mock instance.
+func NewMockCustomResourceDao(ctrl *gomock.Controller) *MockCustomResourceDao {
+	mock := &MockCustomResourceDao{ctrl: ctrl}
+	mock.recorder = &MockCustomResourceDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomResourceDao) EXPECT() *MockCustomResourceDaoMockRecorder {
+	return m.recorder
+}
+
+// CountByKind mocks base method.
+func (m *MockCustomResourceDao) CountByKind(ctx context.Context, kind string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByKind", ctx, kind)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByKind indicates an expected call of CountByKind.
+func (mr *MockCustomResourceDaoMockRecorder) CountByKind(ctx, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByKind", reflect.TypeOf((*MockCustomResourceDao)(nil).CountByKind), ctx, kind)
+}
+
+// Create mocks base method.
+func (m *MockCustomResourceDao) Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, resource)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomResourceDaoMockRecorder) Create(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomResourceDao)(nil).Create), ctx, resource)
+}
+
+// Delete mocks base method.
+func (m *MockCustomResourceDao) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomResourceDaoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomResourceDao)(nil).Delete), ctx, id)
+}
+
+// Get mocks base method.
+func (m *MockCustomResourceDao) Get(ctx context.Context, id string) (*api.CustomResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCustomResourceDaoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCustomResourceDao)(nil).Get), ctx, id)
+}
+
+// Replace mocks base method.
+func (m *MockCustomResourceDao) Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, resource)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockCustomResourceDaoMockRecorder) Replace(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockCustomResourceDao)(nil).Replace), ctx, resource)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dinosaur.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dinosaur.go -destination=mocks/mock_dinosaur.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDinosaurDao is a mock of DinosaurDao interface.
+type MockDinosaurDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockDinosaurDaoMockRecorder
+}
+
+// MockDinosaurDaoMockRecorder is the mock recorder for MockDinosaurDao.
+type MockDinosaurDaoMockRecorder struct {
+	mock *MockDinosaurDao
+}
+
+// NewMockDinosaurDao creates a new mock instance.
+func NewMockDinosaurDao(ctrl *gomock.Controller) *MockDinosaurDao {
+	mock := &MockDinosaurDao{ctrl: ctrl}
+	mock.recorder = &MockDinosaurDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDinosaurDao) EXPECT() *MockDinosaurDaoMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockDinosaurDao) All(ctx context.Context) (api.DinosaurList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// All indicates an expected call of All.
+func (mr *MockDinosaurDaoMockRecorder) All(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockDinosaurDao)(nil).All), ctx)
+}
+
+// Create mocks base method.
+func (m *MockDinosaurDao) Create(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dinosaur)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDinosaurDaoMockRecorder) Create(ctx, dinosaur any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDinosaurDao)(nil).Create), ctx, dinosaur)
+}
+
+// Delete mocks base method.
+func (m *MockDinosaurDao) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDinosaurDaoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDinosaurDao)(nil).Delete), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockDinosaurDao) FindByIDs(ctx context.Context, ids []string) (api.DinosaurList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockDinosaurDaoMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockDinosaurDao)(nil).FindByIDs), ctx, ids)
+}
+
+// FindBySpecies mocks base method.
+func (m *MockDinosaurDao) FindBySpecies(ctx context.Context, species string) (api.DinosaurList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySpecies", ctx, species)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySpecies indicates an expected call of FindBySpecies.
+func (mr *MockDinosaurDaoMockRecorder) FindBySpecies(ctx, species any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySpecies", reflect.TypeOf((*MockDinosaurDao)(nil).FindBySpecies), ctx, species)
+}
+
+// Get mocks base method.
+func (m *MockDinosaurDao) Get(ctx context.Context, id string) (*api.Dinosaur, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDinosaurDaoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDinosaurDao)(nil).Get), ctx, id)
+}
+
+// Replace mocks base method.
+func (m *MockDinosaurDao) Replace(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, dinosaur)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockDinosaurDaoMockRecorder) Replace(ctx, dinosaur any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockDinosaurDao)(nil).Replace), ctx, dinosaur)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mocks/mock_event.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventDao is a mock of EventDao interface.
+type MockEventDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventDaoMockRecorder
+}
+
+// MockEventDaoMockRecorder is the mock recorder for MockEventDao.
+type MockEventDaoMockRecorder struct {
+	mock *MockEventDao
+}
+
+// NewMockEventDao creates a new mock instance.
+func NewMockEventDao(ctrl *gomock.Controller) *MockEventDao {
+	mock := &MockEventDao{ctrl: ctrl}
+	mock.recorder = &MockEventDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventDao) EXPECT() *MockEventDaoMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockEventDao) All(ctx context.Context) (api.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx)
+	ret0, _ := ret[0].(api.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// All indicates an expected call of All.
+func (mr *MockEventDaoMockRecorder) All(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockEventDao)(nil).All), ctx)
+}
+
+// Create mocks base method.
+func (m *MockEventDao) Create(ctx context.Context, event *api.Event) (*api.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, event)
+	ret0, _ := ret[0].(*api.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockEventDaoMockRecorder) Create(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockEventDao)(nil).Create), ctx, event)
+}
+
+// Delete mocks base method.
+func (m *MockEventDao) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockEventDaoMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockEventDao)(nil).Delete), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockEventDao) FindByIDs(ctx context.Context, ids []string) (api.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].(api.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockEventDaoMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockEventDao)(nil).FindByIDs), ctx, ids)
+}
+
+// Get mocks base method.
+func (m *MockEventDao) Get(ctx context.Context, id string) (*api.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*api.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockEventDaoMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockEventDao)(nil).Get), ctx, id)
+}
+
+// Replace mocks base method.
+func (m *MockEventDao) Replace(ctx context.Context, event *api.Event) (*api.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, event)
+	ret0, _ := ret[0].(*api.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockEventDaoMockRecorder) Replace(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockEventDao)(nil).Replace), ctx, event)
+}
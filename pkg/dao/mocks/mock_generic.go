@@ -0,0 +1,184 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: generic.go
+//
+// Generated by this command:
+//
+//	mockgen -source=generic.go -destination=mocks/mock_generic.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dao "github.com/openshift-online/rh-trex/pkg/dao"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGenericDao is a mock of GenericDao interface.
+type MockGenericDao struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenericDaoMockRecorder
+}
+
+// MockGenericDaoMockRecorder is the mock recorder for MockGenericDao.
+type MockGenericDaoMockRecorder struct {
+	mock *MockGenericDao
+}
+
+// NewMockGenericDao creates a new mock instance.
+func NewMockGenericDao(ctrl *gomock.Controller) *MockGenericDao {
+	mock := &MockGenericDao{ctrl: ctrl}
+	mock.recorder = &MockGenericDaoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenericDao) EXPECT() *MockGenericDaoMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockGenericDao) Count(model any, total *int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Count", model, total)
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockGenericDaoMockRecorder) Count(model, total any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockGenericDao)(nil).Count), model, total)
+}
+
+// Fetch mocks base method.
+func (m *MockGenericDao) Fetch(offset, limit int, resourceList any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fetch", offset, limit, resourceList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fetch indicates an expected call of Fetch.
+func (mr *MockGenericDaoMockRecorder) Fetch(offset, limit, resourceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fetch", reflect.TypeOf((*MockGenericDao)(nil).Fetch), offset, limit, resourceList)
+}
+
+// GetInstanceDao mocks base method.
+func (m *MockGenericDao) GetInstanceDao(ctx context.Context, model any) dao.GenericDao {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstanceDao", ctx, model)
+	ret0, _ := ret[0].(dao.GenericDao)
+	return ret0
+}
+
+// GetInstanceDao indicates an expected call of GetInstanceDao.
+func (mr *MockGenericDaoMockRecorder) GetInstanceDao(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceDao", reflect.TypeOf((*MockGenericDao)(nil).GetInstanceDao), ctx, model)
+}
+
+// GetTableName mocks base method.
+func (m *MockGenericDao) GetTableName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// GetTableName indicates an expected call of GetTableName.
+func (mr *MockGenericDaoMockRecorder) GetTableName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableName", reflect.TypeOf((*MockGenericDao)(nil).GetTableName))
+}
+
+// GetTableRelation mocks base method.
+func (m *MockGenericDao) GetTableRelation(fieldName string) (dao.TableRelation, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableRelation", fieldName)
+	ret0, _ := ret[0].(dao.TableRelation)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetTableRelation indicates an expected call of GetTableRelation.
+func (mr *MockGenericDaoMockRecorder) GetTableRelation(fieldName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableRelation", reflect.TypeOf((*MockGenericDao)(nil).GetTableRelation), fieldName)
+}
+
+// Group mocks base method.
+func (m *MockGenericDao) Group(sql string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Group", sql)
+}
+
+// Group indicates an expected call of Group.
+func (mr *MockGenericDaoMockRecorder) Group(sql any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Group", reflect.TypeOf((*MockGenericDao)(nil).Group), sql)
+}
+
+// Joins mocks base method.
+func (m *MockGenericDao) Joins(sql string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Joins", sql)
+}
+
+// Joins indicates an expected call of Joins.
+func (mr *MockGenericDaoMockRecorder) Joins(sql any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Joins", reflect.TypeOf((*MockGenericDao)(nil).Joins), sql)
+}
+
+// OrderBy mocks base method.
+func (m *MockGenericDao) OrderBy(orderBy string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OrderBy", orderBy)
+}
+
+// OrderBy indicates an expected call of OrderBy.
+func (mr *MockGenericDaoMockRecorder) OrderBy(orderBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OrderBy", reflect.TypeOf((*MockGenericDao)(nil).OrderBy), orderBy)
+}
+
+// Preload mocks base method.
+func (m *MockGenericDao) Preload(preload string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Preload", preload)
+}
+
+// Preload indicates an expected call of Preload.
+func (mr *MockGenericDaoMockRecorder) Preload(preload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preload", reflect.TypeOf((*MockGenericDao)(nil).Preload), preload)
+}
+
+// Validate mocks base method.
+func (m *MockGenericDao) Validate(resourceList any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Validate", resourceList)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Validate indicates an expected call of Validate.
+func (mr *MockGenericDaoMockRecorder) Validate(resourceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockGenericDao)(nil).Validate), resourceList)
+}
+
+// Where mocks base method.
+func (m *MockGenericDao) Where(sql string, values []any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Where", sql, values)
+}
+
+// Where indicates an expected call of Where.
+func (mr *MockGenericDaoMockRecorder) Where(sql, values any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Where", reflect.TypeOf((*MockGenericDao)(nil).Where), sql, values)
+}
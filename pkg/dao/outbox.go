@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/db"
+)
+
+//go:generate mockgen -source=outbox.go -destination=mocks/mock_outbox.go -package=mocks
+type OutboxDao interface {
+	// Offset returns how far publisher has got, an empty offset if it hasn't published anything yet
+	Offset(ctx context.Context, publisher string) (*api.OutboxOffset, error)
+	SaveOffset(ctx context.Context, offset *api.OutboxOffset) error
+	// Events returns up to limit events following offset that were created at or before until, oldest first
+	Events(ctx context.Context, offset *api.OutboxOffset, until time.Time, limit int) (api.EventList, error)
+}
+
+var _ OutboxDao = &sqlOutboxDao{}
+
+type sqlOutboxDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewOutboxDao(sessionFactory *db.SessionFactory) OutboxDao {
+	return &sqlOutboxDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlOutboxDao) Offset(ctx context.Context, publisher string) (*api.OutboxOffset, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var offset api.OutboxOffset
+	err := g2.Take(&offset, "publisher = ?", publisher).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &api.OutboxOffset{Publisher: publisher}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &offset, nil
+}
+
+func (d *sqlOutboxDao) SaveOffset(ctx context.Context, offset *api.OutboxOffset) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	err := g2.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "publisher"}},
+		DoUpdates: clause.AssignmentColumns([]string{"event_id", "event_created_at", "updated_at"}),
+	}).Create(offset).Error
+	if err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlOutboxDao) Events(ctx context.Context, offset *api.OutboxOffset, until time.Time, limit int) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	events := api.EventList{}
+	err := g2.Where("created_at > ? OR (created_at = ? AND id > ?)", offset.EventCreatedAt, offset.EventCreatedAt, offset.EventID).
+		Where("created_at <= ?", until).
+		Order("created_at, id").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	dbContext "github.com/openshift-online/rh-trex/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex/pkg/logger"
 	"gorm.io/gorm"
 )
@@ -20,9 +22,16 @@ type (
 const (
 	Migrations LockType = "migrations"
 	Dinosaurs  LockType = "dinosaurs"
+	Outbox     LockType = "outbox"
+	// CustomKinds locks a custom kind by its name, CustomResources a custom resource by its id
+	CustomKinds     LockType = "custom_kinds"
+	CustomResources LockType = "custom_resources"
+	// +trex:scaffold:locks
 )
 
 // LockFactory provides the blocking/unblocking locks based on PostgreSQL advisory lock.
+//
+//go:generate mockgen -source=advisory_locks.go -destination=mocks/mock_advisory_locks.go -package=mocks
 type LockFactory interface {
 	// NewAdvisoryLock constructs a new AdvisoryLock that is a blocking PostgreSQL advisory lock
 	// defined by (id, lockType) and returns a UUID as this AdvisoryLock owner id.
@@ -34,13 +43,16 @@ type LockFactory interface {
 
 type AdvisoryLockFactory struct {
 	connection SessionFactory
+	clock      clock.Clock
 	locks      advisoryLockMap
 }
 
 // NewAdvisoryLockFactory returns a new factory with AdvisoryLock stored in it.
-func NewAdvisoryLockFactory(connection SessionFactory) *AdvisoryLockFactory {
+// The clock times how long the locks are held.
+func NewAdvisoryLockFactory(connection SessionFactory, clock clock.Clock) *AdvisoryLockFactory {
 	return &AdvisoryLockFactory{
 		connection: connection,
+		clock:      clock,
 		locks:      make(advisoryLockMap),
 	}
 }
@@ -53,7 +65,7 @@ func (f *AdvisoryLockFactory) NewAdvisoryLock(ctx context.Context, id string, lo
 	// Unlock() will compare UUIDs and ensure only the top level call succeeds.
 	lockOwnerID := uuid.New().String()
 
-	lock, err := newAdvisoryLock(ctx, f.connection)
+	lock, err := newAdvisoryLock(ctx, f.connection, f.clock.Now())
 	if err != nil {
 		return "", err
 	}
@@ -99,7 +111,7 @@ func (f *AdvisoryLockFactory) Unlock(ctx context.Context, uuid string) {
 		}
 
 		UpdateAdvisoryLockCountMetric(lockType, "OK")
-		UpdateAdvisoryLockDurationMetric(lockType, "OK", lock.startTime)
+		UpdateAdvisoryLockDurationMetric(lockType, "OK", f.clock.Now().Sub(lock.startTime))
 
 		log.Info(fmt.Sprintf("Unlocked lock id=%s - owner=%s", lockID, uuid))
 
@@ -133,9 +145,9 @@ type AdvisoryLock struct {
 }
 
 // newAdvisoryLock constructs a new AdvisoryLock object.
-func newAdvisoryLock(ctx context.Context, connection SessionFactory) (*AdvisoryLock, error) {
-	// it requires a new DB session to start the advisory lock.
-	g2 := connection.New(ctx)
+func newAdvisoryLock(ctx context.Context, connection SessionFactory, startTime time.Time) (*AdvisoryLock, error) {
+	// it requires a new DB session to start the advisory lock, not one in the transaction of a write
+	g2 := connection.New(dbContext.WithoutSession(ctx))
 
 	// start a Tx to ensure gorm will obtain/release the lock using a same connection.
 	tx := g2.Begin()
@@ -151,7 +163,7 @@ func newAdvisoryLock(ctx context.Context, connection SessionFactory) (*AdvisoryL
 	return &AdvisoryLock{
 		txid:      txid.ID,
 		g2:        tx,
-		startTime: time.Now(),
+		startTime: startTime,
 	}, nil
 }
 
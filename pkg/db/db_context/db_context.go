@@ -5,6 +5,8 @@ package db_context
 import (
 	"context"
 
+	"gorm.io/gorm"
+
 	"github.com/openshift-online/rh-trex/pkg/db/transaction"
 )
 
@@ -12,6 +14,7 @@ type contextKey int
 
 const (
 	transactionKey contextKey = iota
+	sessionKey
 )
 
 // WithTransaction adds the transaction to the context and returns a new context
@@ -33,3 +36,21 @@ func TxID(ctx context.Context) (id int64, ok bool) {
 	}
 	return tx.TxID(), true
 }
+
+// WithSession adds the session of a gorm transaction to the context and returns a new context. The sessions
+// made with the context are then made on it, so they write in the transaction.
+func WithSession(ctx context.Context, g2 *gorm.DB) context.Context {
+	return context.WithValue(ctx, sessionKey, g2)
+}
+
+// WithoutSession returns a context whose sessions aren't made on the transaction of ctx, e.g. for an advisory
+// lock, which holds a transaction of its own
+func WithoutSession(ctx context.Context) context.Context {
+	return context.WithValue(ctx, sessionKey, (*gorm.DB)(nil))
+}
+
+// Session extracts the session of the transaction from the context
+func Session(ctx context.Context) (g2 *gorm.DB, ok bool) {
+	g2, ok = ctx.Value(sessionKey).(*gorm.DB)
+	return g2, ok && g2 != nil
+}
@@ -1,9 +1,25 @@
 package db_session
 
-import "sync"
+import (
+	"sync"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/clock"
+)
 
 const (
 	disable = "disable"
 )
 
 var once sync.Once
+
+// nowFunc stamps CreatedAt and UpdatedAt with c, in local time like GORM's default, or with the wall
+// clock when the factory wasn't given one
+func nowFunc(c clock.Clock) func() time.Time {
+	if c == nil {
+		c = clock.New()
+	}
+	return func() time.Time {
+		return c.Now().Local()
+	}
+}
@@ -12,8 +12,10 @@ import (
 
 	"github.com/lib/pq"
 
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	dbContext "github.com/openshift-online/rh-trex/pkg/db/db_context"
 	ocmlogger "github.com/openshift-online/rh-trex/pkg/logger"
 )
 
@@ -26,6 +28,8 @@ type Default struct {
 	// - to setup/close connection because GORM V2 removed gorm.Close()
 	// - to work with pq.CopyIn because connection returned by GORM V2 gorm.DB() in "not the same"
 	db *sql.DB
+
+	clock clock.Clock
 }
 
 var _ db.SessionFactory = &Default{}
@@ -97,10 +101,12 @@ func (f *Default) DirectDB() *sql.DB {
 	return f.db
 }
 
-func waitForNotification(l *pq.Listener, callback func(id string)) {
+func waitForNotification(ctx context.Context, l *pq.Listener, callback func(id string)) {
 	logger := ocmlogger.NewOCMLogger(context.Background())
 	for {
 		select {
+		case <-ctx.Done():
+			return
 		case n := <-l.Notify:
 			logger.Infof("Received data from channel [%s] : %s", n.Channel, n.Extra)
 			callback(n.Extra)
@@ -130,8 +136,11 @@ func newListener(ctx context.Context, connstr, channel string, callback func(id
 	}
 
 	logger.Infof("Starting channeling monitor for %s", channel)
-	for {
-		waitForNotification(listener, callback)
+	for ctx.Err() == nil {
+		waitForNotification(ctx, listener, callback)
+	}
+	if err := listener.Close(); err != nil {
+		logger.Error(err.Error())
 	}
 }
 
@@ -139,10 +148,24 @@ func (f *Default) NewListener(ctx context.Context, channel string, callback func
 	newListener(ctx, f.config.ConnectionString(true), channel, callback)
 }
 
+func (f *Default) Notify(ctx context.Context, channel, payload string) error {
+	return pgNotify(f.New(ctx), channel, payload)
+}
+
+// pgNotify notifies postgres listeners, see newListener
+func pgNotify(g2 *gorm.DB, channel, payload string) error {
+	return g2.Exec("select pg_notify(?, ?)", channel, payload).Error
+}
+
 func (f *Default) New(ctx context.Context) *gorm.DB {
+	if tx, ok := dbContext.Session(ctx); ok {
+		// the sessions of a transaction's context write in it
+		return tx.WithContext(ctx)
+	}
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
 		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		NowFunc: nowFunc(f.clock),
 	})
 	if f.config.Debug {
 		conn = conn.Debug()
@@ -150,6 +173,10 @@ func (f *Default) New(ctx context.Context) *gorm.DB {
 	return conn
 }
 
+func (f *Default) SetClock(c clock.Clock) {
+	f.clock = c
+}
+
 func (f *Default) CheckConnection() error {
 	return f.g2.Exec("SELECT 1").Error
 }
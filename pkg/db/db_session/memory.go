@@ -0,0 +1,147 @@
+package db_session
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	dbContext "github.com/openshift-online/rh-trex/pkg/db/db_context"
+)
+
+// Memory is a SessionFactory backed by an in-memory sqlite database, so unit tests can use the real
+// DAOs and services without a postgres server. Every factory has its own migrated database.
+//
+// The postgres specific pieces are replaced in process: notifications are delivered to the listeners
+// of the same factory, and advisory locks are left to db/mocks.MockAdvisoryLockFactory.
+//
+// The database lives on a single connection, so a transaction begun on DirectDB() blocks every other
+// session until it ends.
+type Memory struct {
+	config *config.DatabaseConfig
+	g2     *gorm.DB
+	db     *sql.DB
+	clock  clock.Clock
+
+	mu        sync.RWMutex
+	listeners map[string]map[int]func(id string)
+	nextID    int
+}
+
+var _ db.SessionFactory = &Memory{}
+
+func NewMemoryFactory(config *config.DatabaseConfig) *Memory {
+	conn := &Memory{}
+	conn.Init(config)
+	return conn
+}
+
+// Init creates and migrates a new in-memory database
+func (f *Memory) Init(config *config.DatabaseConfig) {
+	f.config = config
+	f.listeners = map[string]map[int]func(id string){}
+	f.db, f.g2 = connectMemory()
+}
+
+func connectMemory() (*sql.DB, *gorm.DB) {
+	conf := &gorm.Config{
+		PrepareStmt:            false,
+		FullSaveAssociations:   false,
+		SkipDefaultTransaction: true,
+		Logger:                 logger.Default.LogMode(logger.Silent),
+	}
+	g2, err := gorm.Open(sqlite.Open(":memory:"), conf)
+	if err != nil {
+		panic(fmt.Sprintf("GORM failed to open in-memory sqlite database: %s", err.Error()))
+	}
+	dbx, err := g2.DB()
+	if err != nil {
+		panic(fmt.Sprintf("GORM failed to open in-memory sqlite database: %s", err.Error()))
+	}
+
+	// every connection to :memory: is a different database, and it is gone once the connection
+	// closes, so exactly one connection is kept open
+	dbx.SetMaxOpenConns(1)
+	dbx.SetMaxIdleConns(1)
+	dbx.SetConnMaxLifetime(0)
+
+	if err := db.Migrate(g2); err != nil {
+		panic(fmt.Sprintf("Failed to migrate in-memory sqlite database: %s", err.Error()))
+	}
+	return dbx, g2
+}
+
+func (f *Memory) DirectDB() *sql.DB {
+	return f.db
+}
+
+func (f *Memory) New(ctx context.Context) *gorm.DB {
+	if tx, ok := dbContext.Session(ctx); ok {
+		// the sessions of a transaction's context write in it
+		return tx.WithContext(ctx)
+	}
+	conn := f.g2.Session(&gorm.Session{
+		Context: ctx,
+		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		NowFunc: nowFunc(f.clock),
+	})
+	if f.config != nil && f.config.Debug {
+		conn = conn.Debug()
+	}
+	return conn
+}
+
+func (f *Memory) SetClock(c clock.Clock) {
+	f.clock = c
+}
+
+func (f *Memory) CheckConnection() error {
+	return f.g2.Exec("SELECT 1").Error
+}
+
+func (f *Memory) Close() error {
+	return f.db.Close()
+}
+
+// ResetDB replaces the database with a new, empty one
+func (f *Memory) ResetDB() {
+	if err := f.db.Close(); err != nil {
+		panic(err)
+	}
+	f.db, f.g2 = connectMemory()
+}
+
+// NewListener calls callback with every payload notified on channel, until ctx is done
+func (f *Memory) NewListener(ctx context.Context, channel string, callback func(id string)) {
+	f.mu.Lock()
+	id := f.nextID
+	f.nextID++
+	if f.listeners[channel] == nil {
+		f.listeners[channel] = map[int]func(id string){}
+	}
+	f.listeners[channel][id] = callback
+	f.mu.Unlock()
+
+	<-ctx.Done()
+
+	f.mu.Lock()
+	delete(f.listeners[channel], id)
+	f.mu.Unlock()
+}
+
+// Notify calls the listeners of channel asynchronously, like postgres notifications
+func (f *Memory) Notify(ctx context.Context, channel, payload string) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for _, callback := range f.listeners[channel] {
+		go callback(payload)
+	}
+	return nil
+}
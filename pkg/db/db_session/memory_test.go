@@ -0,0 +1,53 @@
+package db_session
+
+import (
+	"context"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/config"
+)
+
+func TestMemoryNotify(t *testing.T) {
+	gm.RegisterTestingT(t)
+	factory := NewMemoryFactory(config.NewDatabaseConfig())
+	defer factory.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan string, 1)
+	go factory.NewListener(ctx, "events", func(id string) {
+		select {
+		case received <- id:
+		default:
+		}
+	})
+
+	// the listener registers asynchronously, keep notifying until it does
+	gm.Eventually(func() string {
+		gm.Expect(factory.Notify(context.Background(), "events", "42")).To(gm.Succeed())
+		select {
+		case id := <-received:
+			return id
+		default:
+			return ""
+		}
+	}).Should(gm.Equal("42"))
+}
+
+func TestMemoryResetDB(t *testing.T) {
+	gm.RegisterTestingT(t)
+	factory := NewMemoryFactory(config.NewDatabaseConfig())
+	defer factory.Close()
+
+	g2 := factory.New(context.Background())
+	gm.Expect(g2.Exec("INSERT INTO dinosaurs (id, species) VALUES ('1', 'Fukuisaurus')").Error).To(gm.Succeed())
+	var count int64
+	gm.Expect(g2.Table("dinosaurs").Count(&count).Error).To(gm.Succeed())
+	gm.Expect(count).To(gm.Equal(int64(1)))
+
+	factory.ResetDB()
+	gm.Expect(factory.New(context.Background()).Table("dinosaurs").Count(&count).Error).To(gm.Succeed())
+	gm.Expect(count).To(gm.Equal(int64(0)))
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang/glog"
+	"github.com/segmentio/ksuid"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	dbContext "github.com/openshift-online/rh-trex/pkg/db/db_context"
 )
 
 type Test struct {
@@ -26,6 +31,7 @@ type Test struct {
 	db *sql.DB
 
 	wasDisconnected bool
+	clock           clock.Clock
 }
 
 var _ db.SessionFactory = &Test{}
@@ -36,6 +42,27 @@ func NewTestFactory(config *config.DatabaseConfig) *Test {
 	return conn
 }
 
+// NewIsolatedTestFactory creates a database of its own, cloned from template1 under a unique name, so
+// tests using it can run in parallel without seeing each other's rows. Drop removes the database.
+func NewIsolatedTestFactory(config *config.DatabaseConfig) (*Test, error) {
+	isolated := *config
+	isolated.Name = fmt.Sprintf("%s_%s", config.Name, strings.ToLower(ksuid.New().String()))
+
+	if err := initTemplate(&isolated); err != nil {
+		return nil, fmt.Errorf("error initializing test database template: %s", err)
+	}
+	if err := resetDB(&isolated); err != nil {
+		return nil, fmt.Errorf("error creating test database %s: %s", isolated.Name, err)
+	}
+
+	conn := &Test{config: &isolated}
+	var err error
+	if conn.db, conn.g2, err = connectFactory(&isolated); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
 // The approach:
 // Every new Postgres database is implicitly copied from a template database `template1`. Any changes to template1
 // are then copied to a new database, and this copy is a cheap filesystem operation.
@@ -49,7 +76,7 @@ func NewTestFactory(config *config.DatabaseConfig) *Test {
 func (f *Test) Init(config *config.DatabaseConfig) {
 	// Only the first time
 	once.Do(func() {
-		if err := initDatabase(config, db.Migrate); err != nil {
+		if err := initTemplate(config); err != nil {
 			glog.Errorf("error initializing test database: %s", err)
 			return
 		}
@@ -61,12 +88,28 @@ func (f *Test) Init(config *config.DatabaseConfig) {
 	})
 
 	f.config = config
-	f.db, f.g2 = connectFactory(config)
+	f.db, f.g2 = mustConnectFactory(config)
+}
+
+var (
+	templateOnce sync.Once
+	templateErr  error
+)
+
+// initTemplate migrates template1, only the first time it is called
+func initTemplate(config *config.DatabaseConfig) error {
+	templateOnce.Do(func() {
+		templateErr = initDatabase(config, db.Migrate)
+	})
+	return templateErr
 }
 
 func initDatabase(config *config.DatabaseConfig, migrate func(db2 *gorm.DB) error) error {
 	// - Connect to `template1` DB
-	dbx, g2, cleanup := connect("template1", config)
+	dbx, g2, cleanup, err := connect("template1", config)
+	if err != nil {
+		return err
+	}
 	defer cleanup()
 
 	for _, err := dbx.Exec(`select 1`); err != nil; {
@@ -79,7 +122,10 @@ func initDatabase(config *config.DatabaseConfig, migrate func(db2 *gorm.DB) erro
 
 func resetDB(config *config.DatabaseConfig) error {
 	// Reconnect to the default `postgres` database, so we can drop the existing db and recreate it
-	dbx, _, cleanup := connect("postgres", config)
+	dbx, _, cleanup, err := connect("postgres", config)
+	if err != nil {
+		return err
+	}
 	defer cleanup()
 
 	// Drop `all` connections to both `template1` and AMS DB, so it can be dropped and created
@@ -96,15 +142,40 @@ func resetDB(config *config.DatabaseConfig) error {
 		return fmt.Errorf("SQL failed to DROP database %s: %s", config.Name, err.Error())
 	}
 	query = fmt.Sprintf("CREATE DATABASE %s TEMPLATE template1", config.Name)
-	if _, err := dbx.Exec(query); err != nil {
+	// Another test process connecting to `template1` makes the copy fail, so retry for a while
+	for attempt := 0; attempt < 10; attempt++ {
+		if _, err = dbx.Exec(query); err == nil || !strings.Contains(err.Error(), "being accessed by other users") {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if err != nil {
 		return fmt.Errorf("SQL failed to CREATE database %s: %s", config.Name, err.Error())
 	}
 	// As `template1` had all migrations, so now AMS DB has them too!
 	return nil
 }
 
+// dropDB closes the connections to the database of config and drops it
+func dropDB(config *config.DatabaseConfig) error {
+	dbx, _, cleanup, err := connect("postgres", config)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	if err := dropConnections(dbx, config.Name); err != nil {
+		return err
+	}
+	query := fmt.Sprintf("DROP DATABASE IF EXISTS %s", config.Name)
+	if _, err := dbx.Exec(query); err != nil {
+		return fmt.Errorf("SQL failed to DROP database %s: %s", config.Name, err.Error())
+	}
+	return nil
+}
+
 // connect to database specified by `name` and return connections + cleanup function
-func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, func()) {
+func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, func(), error) {
 	var (
 		dbx *sql.DB
 		g2  *gorm.DB
@@ -115,13 +186,13 @@ func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, fun
 	if err != nil {
 		dbx, err = sql.Open(config.Dialect, config.ConnectionStringWithName(name, false))
 		if err != nil {
-			panic(fmt.Sprintf(
+			return nil, nil, nil, fmt.Errorf(
 				"SQL failed to connect to %s database %s with connection string: %s\nError: %s",
 				config.Dialect,
 				name,
 				config.LogSafeConnectionStringWithName(name, config.SSLMode != disable),
 				err.Error(),
-			))
+			)
 		}
 	}
 
@@ -141,20 +212,21 @@ func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, fun
 		PreferSimpleProtocol: true,
 	}), conf)
 	if err != nil {
-		panic(fmt.Sprintf(
+		dbx.Close()
+		return nil, nil, nil, fmt.Errorf(
 			"GORM failed to connect to %s database %s with connection string: %s\nError: %s",
 			config.Dialect,
 			config.Name,
 			config.LogSafeConnectionString(config.SSLMode != disable),
 			err.Error(),
-		))
+		)
 	}
 
 	return dbx, g2, func() {
 		if err := dbx.Close(); err != nil {
 			panic(err)
 		}
-	}
+	}, nil
 }
 
 // KILL all connections to the specified DB
@@ -170,14 +242,22 @@ func dropConnections(dbx *sql.DB, name string) error {
 	return nil
 }
 
-func connectFactory(config *config.DatabaseConfig) (*sql.DB, *gorm.DB) {
-	var (
-		dbx *sql.DB
-		g2  *gorm.DB
-	)
-	dbx, g2, _ = connect(config.Name, config)
+func connectFactory(config *config.DatabaseConfig) (*sql.DB, *gorm.DB, error) {
+	dbx, g2, _, err := connect(config.Name, config)
+	if err != nil {
+		return nil, nil, err
+	}
 	dbx.SetMaxOpenConns(config.MaxOpenConnections)
 
+	return dbx, g2, nil
+}
+
+// mustConnectFactory is connectFactory for the factories shared by the tests, which can't go on without it
+func mustConnectFactory(config *config.DatabaseConfig) (*sql.DB, *gorm.DB) {
+	dbx, g2, err := connectFactory(config)
+	if err != nil {
+		panic(err.Error())
+	}
 	return dbx, g2
 }
 
@@ -186,15 +266,20 @@ func (f *Test) DirectDB() *sql.DB {
 }
 
 func (f *Test) New(ctx context.Context) *gorm.DB {
+	if tx, ok := dbContext.Session(ctx); ok {
+		// the sessions of a transaction's context write in it
+		return tx.WithContext(ctx)
+	}
 	if f.wasDisconnected {
 		// Connection was killed in order to reset DB
-		f.db, f.g2 = connectFactory(f.config)
+		f.db, f.g2 = mustConnectFactory(f.config)
 		f.wasDisconnected = false
 	}
 
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
 		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		NowFunc: nowFunc(f.clock),
 	})
 	if f.config.Debug {
 		conn = conn.Debug()
@@ -202,6 +287,10 @@ func (f *Test) New(ctx context.Context) *gorm.DB {
 	return conn
 }
 
+func (f *Test) SetClock(c clock.Clock) {
+	f.clock = c
+}
+
 // CheckConnection checks to ensure a connection is present
 func (f *Test) CheckConnection() error {
 	_, err := f.db.Exec("SELECT 1")
@@ -217,6 +306,18 @@ func (f *Test) ResetDB() {
 	f.wasDisconnected = true
 }
 
+// Drop closes the factory and drops its database
+func (f *Test) Drop() error {
+	if err := f.db.Close(); err != nil {
+		return err
+	}
+	return dropDB(f.config)
+}
+
 func (f *Test) NewListener(ctx context.Context, channel string, callback func(id string)) {
 	newListener(ctx, f.config.ConnectionString(true), channel, callback)
 }
+
+func (f *Test) Notify(ctx context.Context, channel, payload string) error {
+	return pgNotify(f.New(ctx), channel, payload)
+}
@@ -90,11 +90,10 @@ func UpdateAdvisoryLockCountMetric(lockType LockType, status string) {
 	advisoryLockCountMetric.With(labels).Inc()
 }
 
-func UpdateAdvisoryLockDurationMetric(lockType LockType, status string, startTime time.Time) {
+func UpdateAdvisoryLockDurationMetric(lockType LockType, status string, duration time.Duration) {
 	labels := prometheus.Labels{
 		metricsTypeLabel:   string(lockType),
 		metricsStatusLabel: status,
 	}
-	duration := time.Since(startTime)
 	advisoryLockDurationMetric.With(labels).Observe(duration.Seconds())
 }
@@ -2,7 +2,6 @@ package db
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/go-gormigrate/gormigrate/v2"
 	"github.com/golang/glog"
@@ -39,24 +38,9 @@ func newGormigrate(g2 *gorm.DB) *gormigrate.Gormigrate {
 	return gormigrate.New(g2, gormigrate.DefaultOptions, migrations.MigrationList)
 }
 
-type fkMigration struct {
-	Model     string
-	Dest      string
-	Field     string
-	Reference string
-}
-
-func CreateFK(g2 *gorm.DB, fks ...fkMigration) error {
-	var query = `ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE RESTRICT ON UPDATE RESTRICT;`
-	var drop = `ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;`
-
-	for _, fk := range fks {
-		name := fmt.Sprintf("fk_%s_%s", fk.Model, fk.Dest)
+// FKMigration is kept here so callers outside of migrations can keep using db.CreateFK.
+type FKMigration = migrations.FKMigration
 
-		g2.Exec(fmt.Sprintf(drop, fk.Model, name))
-		if err := g2.Exec(fmt.Sprintf(query, fk.Model, name, fk.Field, fk.Reference)).Error; err != nil {
-			return err
-		}
-	}
-	return nil
+func CreateFK(g2 *gorm.DB, fks ...FKMigration) error {
+	return migrations.CreateFK(g2, fks...)
 }
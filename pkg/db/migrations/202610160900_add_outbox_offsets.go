@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addOutboxOffsets() *gormigrate.Migration {
+	// OutboxOffset has no Model, it is keyed by the name of the publisher and never deleted
+	type OutboxOffset struct {
+		Publisher      string `gorm:"primaryKey"`
+		EventID        string
+		EventCreatedAt time.Time
+		UpdatedAt      time.Time
+	}
+
+	type Event struct {
+		CreatedAt time.Time `gorm:"index:idx_events_created_at_id,priority:1"`
+		ID        string    `gorm:"index:idx_events_created_at_id,priority:2"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610160900",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&OutboxOffset{}); err != nil {
+				return err
+			}
+			// the outbox relays read the events in (created_at, id) order
+			return tx.Migrator().CreateIndex(&Event{}, "idx_events_created_at_id")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&Event{}, "idx_events_created_at_id"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&OutboxOffset{})
+		},
+	}
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func addCustomKinds() *gormigrate.Migration {
+	type CustomKind struct {
+		Model
+		// the name of a deleted kind can be used again
+		Name   string `gorm:"uniqueIndex:idx_custom_kinds_name,where:deleted_at IS NULL"`
+		Schema string `gorm:"type:jsonb"`
+	}
+
+	// CustomResource is the resource of every custom kind, its properties are searched with ->>
+	type CustomResource struct {
+		Model
+		Kind       string `gorm:"index"`
+		Properties string `gorm:"type:jsonb"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "202610161300",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&CustomKind{}); err != nil {
+				return err
+			}
+			return tx.AutoMigrate(&CustomResource{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&CustomResource{}); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&CustomKind{})
+		},
+	}
+}
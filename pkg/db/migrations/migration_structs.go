@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
@@ -27,6 +28,9 @@ import (
 var MigrationList = []*gormigrate.Migration{
 	addDinosaurs(),
 	addEvents(),
+	addOutboxOffsets(),
+	addCustomKinds(),
+	// +trex:scaffold:migrations
 }
 
 // Model represents the base model struct. All entities will have this struct embedded.
@@ -36,3 +40,35 @@ type Model struct {
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
+
+// FKMigration describes a foreign key constraint from Model.Field to Reference, e.g.
+//
+//	FKMigration{Model: "eggs", Dest: "dinosaurs", Field: "dinosaur_id", Reference: "dinosaurs(id)"}
+type FKMigration struct {
+	Model     string
+	Dest      string
+	Field     string
+	Reference string
+}
+
+// CreateFK (re)creates the named foreign key constraints. It is idempotent so it is safe to call
+// from a migration that may be re-run. Only postgres can add constraints to existing tables, so on
+// other databases, like the sqlite used by unit tests, it does nothing.
+func CreateFK(g2 *gorm.DB, fks ...FKMigration) error {
+	if g2.Dialector.Name() != "postgres" {
+		return nil
+	}
+
+	var query = `ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE RESTRICT ON UPDATE RESTRICT;`
+	var drop = `ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;`
+
+	for _, fk := range fks {
+		name := fmt.Sprintf("fk_%s_%s", fk.Model, fk.Dest)
+
+		g2.Exec(fmt.Sprintf(drop, fk.Model, name))
+		if err := g2.Exec(fmt.Sprintf(query, fk.Model, name, fk.Field, fk.Reference)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: advisory_locks.go
+//
+// Generated by this command:
+//
+//	mockgen -source=advisory_locks.go -destination=mocks/mock_advisory_locks.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	db "github.com/openshift-online/rh-trex/pkg/db"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLockFactory is a mock of LockFactory interface.
+type MockLockFactory struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockFactoryMockRecorder
+}
+
+// MockLockFactoryMockRecorder is the mock recorder for MockLockFactory.
+type MockLockFactoryMockRecorder struct {
+	mock *MockLockFactory
+}
+
+// NewMockLockFactory creates a new mock instance.
+func NewMockLockFactory(ctrl *gomock.Controller) *MockLockFactory {
+	mock := &MockLockFactory{ctrl: ctrl}
+	mock.recorder = &MockLockFactoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockFactory) EXPECT() *MockLockFactoryMockRecorder {
+	return m.recorder
+}
+
+// NewAdvisoryLock mocks base method.
+func (m *MockLockFactory) NewAdvisoryLock(ctx context.Context, id string, lockType db.LockType) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAdvisoryLock", ctx, id, lockType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAdvisoryLock indicates an expected call of NewAdvisoryLock.
+func (mr *MockLockFactoryMockRecorder) NewAdvisoryLock(ctx, id, lockType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAdvisoryLock", reflect.TypeOf((*MockLockFactory)(nil).NewAdvisoryLock), ctx, id, lockType)
+}
+
+// Unlock mocks base method.
+func (m *MockLockFactory) Unlock(ctx context.Context, uuid string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unlock", ctx, uuid)
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockLockFactoryMockRecorder) Unlock(ctx, uuid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLockFactory)(nil).Unlock), ctx, uuid)
+}
@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
 )
 
@@ -13,8 +14,13 @@ type SessionFactory interface {
 	Init(*config.DatabaseConfig)
 	DirectDB() *sql.DB
 	New(ctx context.Context) *gorm.DB
+	// SetClock sets the clock stamping the CreatedAt and UpdatedAt of the sessions' models
+	SetClock(clock.Clock)
 	CheckConnection() error
 	Close() error
 	ResetDB()
+	// NewListener blocks, calling callback with the payload of every notification sent to channel, until ctx is done
 	NewListener(ctx context.Context, channel string, callback func(id string))
+	// Notify sends payload to the listeners of channel
+	Notify(ctx context.Context, channel, payload string) error
 }
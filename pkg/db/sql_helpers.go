@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/jinzhu/inflection"
@@ -11,6 +12,13 @@ import (
 	"gorm.io/gorm"
 )
 
+var (
+	// fieldName matches column names, optionally qualified by their table, e.g., subscription_labels.key
+	fieldName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+	// propertyField matches the properties ->> '<name>' fields made by propertiesNodeConverter
+	propertyField = regexp.MustCompile(`^properties ->> '[a-zA-Z_][a-zA-Z0-9_\-]*'$`)
+)
+
 // Check if a field name starts with properties.
 func startsWithProperties(s string) bool {
 	return strings.HasPrefix(s, "properties.")
@@ -25,7 +33,8 @@ func hasProperty(n tsl.Node) bool {
 	}
 
 	// If left side hand is not a `properties` identifier, return.
-	if l.Func != tsl.IdentOp || !startsWithProperties(l.Left.(string)) {
+	name, ok := l.Left.(string)
+	if l.Func != tsl.IdentOp || !ok || !startsWithProperties(name) {
 		return false
 	}
 
@@ -33,16 +42,26 @@ func hasProperty(n tsl.Node) bool {
 }
 
 // getField gets the sql field associated with a name.
+// The field is written into the SQL as is, so only plain column names are accepted.
 func getField(name string, disallowedFields map[string]string) (field string, err *errors.ServiceError) {
 	// We want to accept names with trailing and leading spaces
 	trimmedName := strings.Trim(name, " ")
 
 	// Check for properties ->> '<some field name>'
 	if strings.HasPrefix(trimmedName, "properties ->>") {
+		if _, ok := disallowedFields["properties"]; ok || !propertyField.MatchString(trimmedName) {
+			err = errors.BadRequest("%s is not a valid field name", name)
+			return
+		}
 		field = trimmedName
 		return
 	}
 
+	if !fieldName.MatchString(trimmedName) {
+		err = errors.BadRequest("%s is not a valid field name", name)
+		return
+	}
+
 	// Check for nested field, e.g., subscription_labels.key
 	checkName := trimmedName
 	fieldParts := strings.Split(trimmedName, ".")
@@ -137,7 +156,12 @@ func FieldNameWalk(
 	default:
 		// o/w continue walking the tree.
 		if n.Left != nil {
-			l, err = FieldNameWalk(n.Left.(tsl.Node), disallowedFields)
+			left, ok := n.Left.(tsl.Node)
+			if !ok {
+				err = errors.BadRequest("unsupported left hand side type in search query")
+				return
+			}
+			l, err = FieldNameWalk(left, disallowedFields)
 			if err != nil {
 				return
 			}
@@ -0,0 +1,98 @@
+package db
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/yaacov/tree-search-language/pkg/tsl"
+	"github.com/yaacov/tree-search-language/pkg/walkers/ident"
+	sqlFilter "github.com/yaacov/tree-search-language/pkg/walkers/sql"
+)
+
+// the seed corpora are checked in under testdata/fuzz, run the fuzzers with make test-fuzz or e.g.
+//
+//	go test ./pkg/db -run '^$' -fuzz FuzzFieldNameWalk -fuzztime 1m
+
+var fuzzDisallowedFields = map[string]string{
+	"password": "password",
+	"token":    "token",
+}
+
+// propertyFieldInSQL finds the property fields within a statement
+var propertyFieldInSQL = regexp.MustCompile(strings.Trim(propertyField.String(), "^$"))
+
+// checkFieldAllowed fails t unless field is a plain, allowed column name or property
+func checkFieldAllowed(t *testing.T, field string) {
+	if propertyField.MatchString(field) {
+		return
+	}
+	if !fieldName.MatchString(field) {
+		t.Fatalf("field %q reached the SQL", field)
+	}
+	parts := strings.Split(field, ".")
+	if _, disallowed := fuzzDisallowedFields[parts[len(parts)-1]]; disallowed {
+		t.Fatalf("disallowed field %q reached the SQL", field)
+	}
+}
+
+// checkParameterized fails t unless every value of the SQL is a parameter
+func checkParameterized(t *testing.T, sql string, values []interface{}) {
+	// the only quotes allowed are those around property names, which are checked by checkFieldAllowed
+	unquoted := propertyFieldInSQL.ReplaceAllString(sql, "properties")
+	for _, token := range []string{"'", "\"", ";", "--", "/*", "$"} {
+		if strings.Contains(unquoted, token) {
+			t.Fatalf("%q found in SQL %q", token, sql)
+		}
+	}
+	if placeholders := strings.Count(sql, "?"); placeholders != len(values) {
+		t.Fatalf("SQL %q has %d placeholders for %d values", sql, placeholders, len(values))
+	}
+}
+
+func FuzzFieldNameWalk(f *testing.F) {
+	f.Fuzz(func(t *testing.T, search string) {
+		tree, err := tsl.ParseTSL(search)
+		if err != nil {
+			return
+		}
+		tree, serviceErr := FieldNameWalk(tree, fuzzDisallowedFields)
+		if serviceErr != nil {
+			return
+		}
+
+		_, err = ident.Walk(tree, func(field string) (string, error) {
+			checkFieldAllowed(t, field)
+			return field, nil
+		})
+		if err != nil {
+			t.Fatalf("unable to walk the checked tree: %s", err)
+		}
+
+		sqlizer, err := sqlFilter.Walk(tree)
+		if err != nil {
+			return
+		}
+		sql, values, err := sqlizer.ToSql()
+		if err != nil {
+			return
+		}
+		checkParameterized(t, sql, values)
+	})
+}
+
+func FuzzArgsToOrderBy(f *testing.F) {
+	f.Fuzz(func(t *testing.T, orderBy string) {
+		orderByArgs, serviceErr := ArgsToOrderBy(strings.Split(orderBy, ","), fuzzDisallowedFields)
+		if serviceErr != nil {
+			return
+		}
+		for _, arg := range orderByArgs {
+			field, direction, found := strings.Cut(arg, " ")
+			if !found || (direction != "asc" && direction != "desc") {
+				t.Fatalf("bad order by %q", arg)
+			}
+			checkFieldAllowed(t, field)
+		}
+	})
+}
@@ -3,6 +3,9 @@ package db
 import (
 	"context"
 
+	"gorm.io/gorm"
+
+	dbContext "github.com/openshift-online/rh-trex/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex/pkg/db/transaction"
 )
 
@@ -36,3 +39,19 @@ func newTransaction(ctx context.Context, connection SessionFactory) (*transactio
 
 	return transaction.Build(tx, txid, defaultRollbackPolicy), nil
 }
+
+// Transaction calls fn with a context whose sessions write in a transaction of connection, which is committed
+// when fn returns nil and rolled back otherwise. fn joins the transaction of ctx when there is one, so that the
+// writes fn leads to are committed, or rolled back, together. Without connection, as in unit tests, fn runs
+// without a transaction.
+func Transaction(ctx context.Context, connection *SessionFactory, fn func(ctx context.Context) error) error {
+	if connection == nil || *connection == nil {
+		return fn(ctx)
+	}
+	if _, ok := dbContext.Session(ctx); ok {
+		return fn(ctx)
+	}
+	return (*connection).New(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(dbContext.WithSession(ctx, tx))
+	})
+}
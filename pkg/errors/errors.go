@@ -8,17 +8,15 @@ import (
 	"github.com/golang/glog"
 
 	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 const (
 	// Prefix used for error code strings
-	// Example:
-	//   ERROR_CODE_PREFIX = "OCM-EXAMPLE"
-	//   results in: OCM-EXAMPLE-1
-	ERROR_CODE_PREFIX = "OCM-EXAMPLE"
+	ERROR_CODE_PREFIX = identity.ErrorPrefix
 
 	// HREF for API errors
-	ERROR_HREF = "/api/ocm-example-service/v1/errors/"
+	ERROR_HREF = identity.ErrorsPath + "/"
 
 	// InvalidToken occurs when a token is invalid (generally, not found in the database)
 	ErrorInvalidToken ServiceErrorCode = 1
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 )
 
 func TestErrorFormatting(t *testing.T) {
@@ -23,3 +25,20 @@ func TestErrorFind(t *testing.T) {
 	Expect(exists).To(Equal(false))
 	Expect(err).To(BeNil())
 }
+
+func TestErrorGRPCStatus(t *testing.T) {
+	RegisterTestingT(t)
+	for _, err := range Errors() {
+		Expect(err.GRPCCode()).NotTo(Equal(codes.Unknown), "error %d has no gRPC code", err.Code)
+	}
+
+	st := NotFound("no dinosaur %s", "123").AsGRPCStatus("op-1")
+	Expect(st.Code()).To(Equal(codes.NotFound))
+	Expect(st.Message()).To(Equal("no dinosaur 123"))
+	Expect(st.Details()).To(HaveLen(1))
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	Expect(ok).To(BeTrue())
+	Expect(info.Reason).To(Equal(ERROR_CODE_PREFIX + "-7"))
+	Expect(info.Metadata).To(HaveKeyWithValue("operation_id", "op-1"))
+	Expect(info.Metadata).To(HaveKeyWithValue("href", ERROR_HREF+"7"))
+}
@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex/pkg/identity"
+)
+
+// grpcCodes are the gRPC status codes the gRPC API returns the service errors with
+var grpcCodes = map[ServiceErrorCode]codes.Code{
+	ErrorInvalidToken:         codes.Unauthenticated,
+	ErrorForbidden:            codes.PermissionDenied,
+	ErrorConflict:             codes.AlreadyExists,
+	ErrorNotFound:             codes.NotFound,
+	ErrorValidation:           codes.InvalidArgument,
+	ErrorGeneral:              codes.Internal,
+	ErrorNotImplemented:       codes.Unimplemented,
+	ErrorUnauthorized:         codes.PermissionDenied,
+	ErrorUnauthenticated:      codes.Unauthenticated,
+	ErrorMalformedRequest:     codes.InvalidArgument,
+	ErrorBadRequest:           codes.InvalidArgument,
+	ErrorFailedToParseSearch:  codes.InvalidArgument,
+	ErrorDatabaseAdvisoryLock: codes.Unavailable,
+}
+
+// GRPCCode is the gRPC counterpart of HttpCode
+func (e *ServiceError) GRPCCode() codes.Code {
+	if code, found := grpcCodes[e.Code]; found {
+		return code
+	}
+	return codes.Unknown
+}
+
+// AsGRPCStatus is the gRPC counterpart of AsOpenapiError. The error's code, href and the operation ID
+// are in an ErrorInfo detail of the status.
+func (e *ServiceError) AsGRPCStatus(operationID string) *status.Status {
+	st := status.New(e.GRPCCode(), e.Reason)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: *CodeStr(e.Code),
+		Domain: identity.ID,
+		Metadata: map[string]string{
+			"id":           strconv.Itoa(int(e.Code)),
+			"href":         *Href(e.Code),
+			"operation_id": operationID,
+		},
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
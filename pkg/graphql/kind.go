@@ -0,0 +1,146 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/jinzhu/inflection"
+	"gorm.io/gorm/schema"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// Kind is a kind served by the GraphQL API. Its GraphQL type is read from its api struct: the scalar
+// fields become fields named after their columns, and a <Parent>ID field naming another kind also a
+// field resolving to that parent, e.g. dinosaur for an Egg's DinosaurID. The parent in turn gets a field
+// listing its children, e.g. eggs.
+type Kind struct {
+	name string
+	// singular and plural are the kind's names in the REST API's paths, e.g. dinosaur and dinosaurs
+	singular  string
+	plural    string
+	model     reflect.Type
+	findByIDs func(ctx context.Context, ids []string) (map[string]interface{}, *errors.ServiceError)
+}
+
+// NewKind registers the kind findByIDs finds, e.g. NewKind(services.Dinosaurs().FindByIDs). findByIDs
+// is called once for all the resources of the kind a query refers to by id.
+func NewKind[T any, L ~[]*T](findByIDs func(ctx context.Context, ids []string) (L, *errors.ServiceError)) Kind {
+	model := reflect.TypeOf((*T)(nil)).Elem()
+	return Kind{
+		name:     model.Name(),
+		singular: strings.ToLower(model.Name()),
+		plural:   strings.ToLower(inflection.Plural(model.Name())),
+		model:    model,
+		findByIDs: func(ctx context.Context, ids []string) (map[string]interface{}, *errors.ServiceError) {
+			found, err := findByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			index := map[string]interface{}{}
+			for _, o := range found {
+				index[idOf(o)] = o
+			}
+			return index, nil
+		},
+	}
+}
+
+// field is a scalar field of a kind's api struct
+type field struct {
+	name  string
+	index []int
+	typ   gql.Output
+}
+
+// relation is the <Parent>ID field of a kind whose parent is a registered kind
+type relation struct {
+	// name is the field resolving to the parent, column the <parent>_id column
+	name   string
+	column string
+	index  []int
+	parent string
+}
+
+var naming = schema.NamingStrategy{}
+
+// fields reads the scalar fields and relations of k's api struct, given the names of the registered kinds
+func (k Kind) fields(kinds map[string]Kind) ([]field, []relation) {
+	var fields []field
+	var relations []relation
+	for _, f := range reflect.VisibleFields(k.model) {
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		typ, ok := scalar(f.Type)
+		if !ok {
+			// associations, preloaded with ?preload= in the REST API, are resolved from the relations instead
+			continue
+		}
+		column := naming.ColumnName("", f.Name)
+		if f.Name == "ID" {
+			typ = gql.NewNonNull(gql.ID)
+		}
+		fields = append(fields, field{name: column, index: f.Index, typ: typ})
+
+		parent := strings.TrimSuffix(f.Name, "ID")
+		if _, ok := kinds[parent]; ok && parent != f.Name && f.Type.Kind() == reflect.String {
+			relations = append(relations, relation{
+				name:   strings.TrimSuffix(column, "_id"),
+				column: column,
+				index:  f.Index,
+				parent: parent,
+			})
+		}
+	}
+	return fields, relations
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// scalar is the GraphQL type of the Go type t, non-null unless t is a pointer
+func scalar(t reflect.Type) (gql.Output, bool) {
+	if t.Kind() == reflect.Ptr {
+		typ, ok := scalar(t.Elem())
+		if !ok {
+			return nil, false
+		}
+		return typ.(*gql.NonNull).OfType, true
+	}
+
+	var typ gql.Output
+	switch {
+	case t == timeType:
+		typ = gql.DateTime
+	case t.Kind() == reflect.String:
+		typ = gql.String
+	case t.Kind() == reflect.Bool:
+		typ = gql.Boolean
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		typ = gql.Int
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		typ = gql.Float
+	default:
+		return nil, false
+	}
+	return gql.NewNonNull(typ), true
+}
+
+// valueOf is the field at index of the api struct o points to, or nil for nil pointers
+func valueOf(o interface{}, index []int) interface{} {
+	v := reflect.ValueOf(o).Elem().FieldByIndex(index)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return v.Interface()
+}
+
+func idOf(o interface{}) string {
+	return reflect.ValueOf(o).Elem().FieldByName("ID").String()
+}
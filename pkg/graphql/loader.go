@@ -0,0 +1,93 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// loader batches the loads of one kind of resource over a query. graphql-go resolves each level of a
+// query before calling the thunks it returned, so loading the keys queued by the first thunk called loads
+// those of the whole level at once, and a query for 100 eggs and their dinosaurs makes two calls rather
+// than 101.
+type loader struct {
+	mu      sync.Mutex
+	fetch   func(ctx context.Context, keys []string) (map[string]interface{}, *errors.ServiceError)
+	pending []string
+	loaded  map[string]interface{}
+	failed  map[string]*errors.ServiceError
+}
+
+func newLoader(fetch func(ctx context.Context, keys []string) (map[string]interface{}, *errors.ServiceError)) *loader {
+	return &loader{
+		fetch:  fetch,
+		loaded: map[string]interface{}{},
+		failed: map[string]*errors.ServiceError{},
+	}
+}
+
+// load queues key and returns the thunk resolving to its resource, nil if it wasn't found
+func (l *loader) load(ctx context.Context, key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, ok := l.loaded[key]; !ok {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			keys := unique(l.pending)
+			l.pending = nil
+			found, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.failed[k] = err
+					continue
+				}
+				l.loaded[k] = found[k]
+			}
+		}
+		if err := l.failed[key]; err != nil {
+			return nil, handleError(ctx, err)
+		}
+		return l.loaded[key], nil
+	}
+}
+
+func unique(keys []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, k := range keys {
+		if !seen[k] {
+			seen[k] = true
+			result = append(result, k)
+		}
+	}
+	return result
+}
+
+// loaders are the loaders of a query, by kind and relation
+type loaders struct {
+	mu      sync.Mutex
+	loaders map[string]*loader
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{loaders: map[string]*loader{}})
+}
+
+// loaderFor returns the query's loader for name, created with fetch the first time
+func loaderFor(ctx context.Context, name string, fetch func(ctx context.Context, keys []string) (map[string]interface{}, *errors.ServiceError)) *loader {
+	l := ctx.Value(loadersKey{}).(*loaders)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.loaders[name]; !ok {
+		l.loaders[name] = newLoader(fetch)
+	}
+	return l.loaders[name]
+}
@@ -0,0 +1,322 @@
+// Package graphql serves the registered kinds over a read only GraphQL API. Queries resolve through the
+// services, as the REST API's requests do: lists with GenericService.List and the resources they refer to
+// in batches with each kind's FindByIDs.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/identity"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+// defaultPageSize is the size of lists, as in the REST API
+const defaultPageSize = 100
+
+type Schema struct {
+	schema  gql.Schema
+	generic services.GenericService
+	kinds   map[string]Kind
+}
+
+// Request is a GraphQL request, as POSTed to the endpoint
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// NewSchema builds the schema of kinds. For each kind, e.g. Dinosaur, the query has a field getting one by
+// id, dinosaur(id: ID!), and one listing them, dinosaurs(search: String, order_by: [String!], page: Int,
+// size: Int), with the parameters of the REST API's list.
+func NewSchema(generic services.GenericService, kinds ...Kind) (*Schema, error) {
+	s := &Schema{generic: generic, kinds: map[string]Kind{}}
+	for _, k := range kinds {
+		s.kinds[k.name] = k
+	}
+
+	objects := map[string]*gql.Object{}
+	children := map[string][]childList{}
+	for _, k := range kinds {
+		k := k
+		fields, relations := k.fields(s.kinds)
+		objects[k.name] = gql.NewObject(gql.ObjectConfig{
+			Name: k.name,
+			Fields: gql.FieldsThunk(func() gql.Fields {
+				return s.objectFields(k, fields, relations, objects, children[k.name])
+			}),
+		})
+		for _, r := range relations {
+			children[r.parent] = append(children[r.parent], childList{kind: k, relation: r})
+		}
+	}
+
+	query := gql.Fields{}
+	for _, k := range kinds {
+		k := k
+		query[k.singular] = &gql.Field{
+			Type:    objects[k.name],
+			Args:    gql.FieldConfigArgument{"id": {Type: gql.NewNonNull(gql.ID)}},
+			Resolve: s.resolveGet(k),
+		}
+		query[k.plural] = &gql.Field{
+			Type: gql.NewNonNull(gql.NewObject(gql.ObjectConfig{
+				Name: k.name + "List",
+				Fields: gql.Fields{
+					"kind":  {Type: gql.NewNonNull(gql.String)},
+					"page":  {Type: gql.NewNonNull(gql.Int)},
+					"size":  {Type: gql.NewNonNull(gql.Int)},
+					"total": {Type: gql.NewNonNull(gql.Int)},
+					"items": {Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(objects[k.name])))},
+				},
+			})),
+			Args: gql.FieldConfigArgument{
+				"search":   {Type: gql.String, Description: "A TSL query, e.g. species = 'Stegosaurus'"},
+				"order_by": {Type: gql.NewList(gql.NewNonNull(gql.String)), Description: "The columns to sort by, each optionally followed by asc or desc"},
+				"page":     {Type: gql.Int, DefaultValue: 1},
+				"size":     {Type: gql.Int, DefaultValue: defaultPageSize},
+			},
+			Resolve: s.resolveList(k),
+		}
+	}
+
+	schema, err := gql.NewSchema(gql.SchemaConfig{
+		Query: gql.NewObject(gql.ObjectConfig{Name: "Query", Fields: query}),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Unable to build the GraphQL schema: %s", err)
+	}
+	s.schema = schema
+	return s, nil
+}
+
+// Execute runs a query, with the loaders batching its loads
+func (s *Schema) Execute(ctx context.Context, request Request) *gql.Result {
+	result := gql.Do(gql.Params{
+		Schema:         s.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        withLoaders(ctx),
+	})
+	extend(result.Errors)
+	return result
+}
+
+// childList is the field of a parent kind listing the children of kind
+type childList struct {
+	kind     Kind
+	relation relation
+}
+
+func (s *Schema) objectFields(k Kind, fields []field, relations []relation, objects map[string]*gql.Object, children []childList) gql.Fields {
+	result := gql.Fields{
+		"kind": {
+			Type:    gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) { return k.name, nil },
+		},
+		"href": {
+			Type: gql.NewNonNull(gql.String),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				return fmt.Sprintf("%s/%s/%s", identity.BasePath, k.plural, idOf(p.Source)), nil
+			},
+		},
+	}
+	for _, f := range fields {
+		f := f
+		result[f.name] = &gql.Field{
+			Type:    f.typ,
+			Resolve: func(p gql.ResolveParams) (interface{}, error) { return valueOf(p.Source, f.index), nil },
+		}
+	}
+	for _, r := range relations {
+		r := r
+		parent := s.kinds[r.parent]
+		result[r.name] = &gql.Field{
+			Type: objects[r.parent],
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				id := valueOf(p.Source, r.index).(string)
+				if id == "" {
+					return nil, nil
+				}
+				return loaderFor(p.Context, parent.name, parent.findByIDs).load(p.Context, id), nil
+			},
+		}
+	}
+	for _, c := range children {
+		c := c
+		result[c.kind.plural] = &gql.Field{
+			Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(objects[c.kind.name]))),
+			Resolve: func(p gql.ResolveParams) (interface{}, error) {
+				fetch := func(ctx context.Context, ids []string) (map[string]interface{}, *errors.ServiceError) {
+					return s.listChildren(ctx, c, ids)
+				}
+				load := loaderFor(p.Context, c.kind.name+"."+c.relation.column, fetch).load(p.Context, idOf(p.Source))
+				return func() (interface{}, error) {
+					found, err := load()
+					if err != nil {
+						return nil, err
+					}
+					if found == nil {
+						// no children
+						return []interface{}{}, nil
+					}
+					return found, nil
+				}, nil
+			},
+		}
+	}
+	return result
+}
+
+func (s *Schema) resolveGet(k Kind) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		id := p.Args["id"].(string)
+		load := loaderFor(p.Context, k.name, k.findByIDs).load(p.Context, id)
+		return func() (interface{}, error) {
+			found, err := load()
+			if err == nil && found == nil {
+				return nil, handleError(p.Context, errors.NotFound("%s with id='%s' not found", k.name, id))
+			}
+			return found, err
+		}, nil
+	}
+}
+
+func (s *Schema) resolveList(k Kind) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		listArgs := &services.ListArguments{
+			Page: p.Args["page"].(int),
+			Size: int64(p.Args["size"].(int)),
+		}
+		if listArgs.Size > services.MAX_LIST_SIZE || listArgs.Size < 0 {
+			listArgs.Size = services.MAX_LIST_SIZE
+		}
+		if search, ok := p.Args["search"].(string); ok {
+			listArgs.Search = search
+		}
+		if orderBy, ok := p.Args["order_by"].([]interface{}); ok {
+			for _, o := range orderBy {
+				listArgs.OrderBy = append(listArgs.OrderBy, o.(string))
+			}
+		}
+
+		list := reflect.New(reflect.SliceOf(k.model))
+		paging, err := s.generic.List(p.Context, "username", listArgs, list.Interface())
+		if err != nil {
+			return nil, handleError(p.Context, err)
+		}
+		return map[string]interface{}{
+			"kind":  k.name + "List",
+			"page":  paging.Page,
+			"size":  paging.Size,
+			"total": paging.Total,
+			"items": items(list.Elem()),
+		}, nil
+	}
+}
+
+// listChildren lists the children of c's kind of the parents with ids, all pages of them, by parent id
+func (s *Schema) listChildren(ctx context.Context, c childList, ids []string) (map[string]interface{}, *errors.ServiceError) {
+	quoted := make([]string, len(ids))
+	for i, id := range ids {
+		quoted[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(id, "'", "''"))
+	}
+	listArgs := &services.ListArguments{
+		Page:    1,
+		Size:    services.MAX_LIST_SIZE,
+		Search:  fmt.Sprintf("%s in (%s)", c.relation.column, strings.Join(quoted, ", ")),
+		OrderBy: []string{"created_at"},
+	}
+
+	byParent := map[string]interface{}{}
+	for {
+		list := reflect.New(reflect.SliceOf(c.kind.model))
+		paging, err := s.generic.List(ctx, "username", listArgs, list.Interface())
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range items(list.Elem()) {
+			parentID := valueOf(child, c.relation.index).(string)
+			children, _ := byParent[parentID].([]interface{})
+			byParent[parentID] = append(children, child)
+		}
+		if pageEnd(paging) {
+			return byParent, nil
+		}
+		listArgs.Page++
+	}
+}
+
+func pageEnd(paging *api.PagingMeta) bool {
+	return paging.Size == 0 || int64(paging.Page)*paging.Size >= paging.Total
+}
+
+// items are pointers to the api structs of list, the sources of their fields
+func items(list reflect.Value) []interface{} {
+	result := make([]interface{}, list.Len())
+	for i := range result {
+		result[i] = list.Index(i).Addr().Interface()
+	}
+	return result
+}
+
+// serviceError adds the code of a service error and the operation ID to its GraphQL error, as the REST
+// API's error bodies have them
+type serviceError struct {
+	err         *errors.ServiceError
+	operationID string
+}
+
+var _ gqlerrors.ExtendedError = serviceError{}
+
+func (e serviceError) Error() string {
+	return e.err.Reason
+}
+
+func (e serviceError) Extensions() map[string]interface{} {
+	return map[string]interface{}{
+		"code":         *errors.CodeStr(e.err.Code),
+		"href":         *errors.Href(e.err.Code),
+		"operation_id": e.operationID,
+	}
+}
+
+func handleError(ctx context.Context, err *errors.ServiceError) error {
+	log := logger.NewOCMLogger(ctx)
+	// If this is a 400 error, its the user's issue, log as info rather than error
+	if err.HttpCode >= 400 && err.HttpCode <= 499 {
+		log.Infof(err.Error())
+	} else {
+		log.Error(err.Error())
+	}
+	return serviceError{err: err, operationID: logger.GetOperationID(ctx)}
+}
+
+// extend adds the extensions of service errors graphql-go dropped, which it does for those of thunks
+func extend(errs []gqlerrors.FormattedError) {
+	for i := range errs {
+		var err error = errs[i]
+		for err != nil && errs[i].Extensions == nil {
+			switch e := err.(type) {
+			case serviceError:
+				errs[i].Extensions = e.Extensions()
+			case gqlerrors.FormattedError:
+				err = e.OriginalError()
+			case *gqlerrors.Error:
+				err = e.OriginalError
+			default:
+				err = nil
+			}
+		}
+	}
+}
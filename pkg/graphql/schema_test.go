@@ -0,0 +1,170 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+	"github.com/openshift-online/rh-trex/pkg/services/mocks"
+)
+
+// Egg is a kind belonging to Dinosaur, as the generator's --belongs-to creates them
+type Egg struct {
+	api.Meta
+	DinosaurID string
+	Dinosaur   *api.Dinosaur
+	Weight     *int
+}
+
+type EggList []*Egg
+
+var created = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func dinosaur(id, species string) api.Dinosaur {
+	return api.Dinosaur{Meta: api.Meta{ID: id, CreatedAt: created, UpdatedAt: created}, Species: species}
+}
+
+func egg(id, dinosaurID string) Egg {
+	return Egg{Meta: api.Meta{ID: id, CreatedAt: created, UpdatedAt: created}, DinosaurID: dinosaurID}
+}
+
+// fixture serves the dinosaurs and eggs through a GenericService mock and FindByIDs funcs, recording
+// the searches listed and the ids found
+type fixture struct {
+	dinosaurs []api.Dinosaur
+	eggs      []Egg
+	searches  []string
+	found     [][]string
+}
+
+func (f *fixture) schema(t *testing.T) *Schema {
+	generic := mocks.NewMockGenericService(gomock.NewController(t))
+	generic.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().
+		DoAndReturn(func(ctx context.Context, username string, args *services.ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+			f.searches = append(f.searches, args.Search)
+			var total int
+			switch list := resourceList.(type) {
+			case *[]api.Dinosaur:
+				*list = append(*list, f.dinosaurs...)
+				total = len(f.dinosaurs)
+			case *[]Egg:
+				*list = append(*list, f.eggs...)
+				total = len(f.eggs)
+			}
+			return &api.PagingMeta{Page: args.Page, Size: int64(total), Total: int64(total)}, nil
+		})
+
+	schema, err := NewSchema(generic,
+		NewKind(func(ctx context.Context, ids []string) (api.DinosaurList, *errors.ServiceError) {
+			f.found = append(f.found, ids)
+			var found api.DinosaurList
+			for i := range f.dinosaurs {
+				for _, id := range ids {
+					if f.dinosaurs[i].ID == id {
+						found = append(found, &f.dinosaurs[i])
+					}
+				}
+			}
+			return found, nil
+		}),
+		NewKind(func(ctx context.Context, ids []string) (EggList, *errors.ServiceError) {
+			return nil, errors.GeneralError("eggs aren't found by id in this test")
+		}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	return schema
+}
+
+func execute(schema *Schema, query string) string {
+	result := schema.Execute(context.Background(), Request{Query: query})
+	Expect(result.Errors).To(BeEmpty())
+	data, err := json.Marshal(result.Data)
+	Expect(err).NotTo(HaveOccurred())
+	return string(data)
+}
+
+func TestListWithChildren(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := &fixture{
+		dinosaurs: []api.Dinosaur{dinosaur("1", "Triceratops"), dinosaur("2", "Stegosaurus")},
+		eggs:      []Egg{egg("a", "1"), egg("b", "1")},
+	}
+	data := execute(f.schema(t), `{
+		dinosaurs(search: "species like '%a%'", size: 10) {
+			kind total items { id kind href species created_at eggs { id weight } }
+		}
+	}`)
+	Expect(data).To(MatchJSON(`{
+		"dinosaurs": {
+			"kind": "DinosaurList",
+			"total": 2,
+			"items": [
+				{
+					"id": "1", "kind": "Dinosaur", "href": "/api/ocm-example-service/v1/dinosaurs/1",
+					"species": "Triceratops", "created_at": "2024-01-02T03:04:05Z",
+					"eggs": [{"id": "a", "weight": null}, {"id": "b", "weight": null}]
+				},
+				{
+					"id": "2", "kind": "Dinosaur", "href": "/api/ocm-example-service/v1/dinosaurs/2",
+					"species": "Stegosaurus", "created_at": "2024-01-02T03:04:05Z",
+					"eggs": []
+				}
+			]
+		}
+	}`))
+
+	// the eggs of all the dinosaurs are listed at once
+	Expect(f.searches).To(Equal([]string{"species like '%a%'", "dinosaur_id in ('1', '2')"}))
+}
+
+func TestParentsFoundInBatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := &fixture{
+		dinosaurs: []api.Dinosaur{dinosaur("1", "Triceratops"), dinosaur("2", "Stegosaurus")},
+		eggs:      []Egg{egg("a", "1"), egg("b", "2"), egg("c", "1"), egg("d", "")},
+	}
+	data := execute(f.schema(t), `{
+		eggs { items { id dinosaur_id dinosaur { species } } }
+		dinosaur(id: "2") { id }
+	}`)
+	Expect(data).To(MatchJSON(`{
+		"eggs": {"items": [
+			{"id": "a", "dinosaur_id": "1", "dinosaur": {"species": "Triceratops"}},
+			{"id": "b", "dinosaur_id": "2", "dinosaur": {"species": "Stegosaurus"}},
+			{"id": "c", "dinosaur_id": "1", "dinosaur": {"species": "Triceratops"}},
+			{"id": "d", "dinosaur_id": "", "dinosaur": null}
+		]},
+		"dinosaur": {"id": "2"}
+	}`))
+
+	// the dinosaurs of the query are found at once, however deep in it they are
+	Expect(f.found).To(HaveLen(1))
+	Expect(f.found[0]).To(ConsistOf("1", "2"))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	f := &fixture{}
+	result := f.schema(t).Execute(context.Background(), Request{
+		Query:     `query Get($id: ID!) { dinosaur(id: $id) { id } }`,
+		Variables: map[string]interface{}{"id": "404"},
+	})
+	Expect(result.Errors).To(HaveLen(1))
+	Expect(result.Errors[0].Message).To(Equal("Dinosaur with id='404' not found"))
+	Expect(result.Errors[0].Path).To(Equal([]interface{}{"dinosaur"}))
+	Expect(result.Errors[0].Extensions).To(HaveKeyWithValue("code", "OCM-EXAMPLE-7"))
+
+	// the API is read only
+	result = f.schema(t).Execute(context.Background(), Request{Query: `mutation { dinosaur(id: "1") { id } }`})
+	Expect(result.Errors).To(HaveLen(1))
+}
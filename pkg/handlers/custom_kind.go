@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+type customKindHandler struct {
+	customKind services.CustomKindService
+	generic    services.GenericService
+}
+
+func NewCustomKindHandler(customKind services.CustomKindService, generic services.GenericService) *customKindHandler {
+	return &customKindHandler{
+		customKind: customKind,
+		generic:    generic,
+	}
+}
+
+func (h customKindHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var customKind openapi.CustomKind
+	cfg := &handlerConfig{
+		&customKind,
+		[]validate{
+			validateEmpty(&customKind, "Id", "id"),
+			validateNotEmpty(&customKind, "Name", "name"),
+			validateNotNil(&customKind, "Schema", "schema"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			kind, err := h.customKind.Create(ctx, presenters.ConvertCustomKind(customKind))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentCustomKind(kind), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h customKindHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			var kinds = []api.CustomKind{}
+			paging, err := h.generic.List(ctx, "username", listArgs, &kinds)
+			if err != nil {
+				return nil, err
+			}
+			kindList := openapi.CustomKindList{
+				Kind:  "CustomKindList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.CustomKind{},
+			}
+
+			for _, kind := range kinds {
+				converted := presenters.PresentCustomKind(&kind)
+				kindList.Items = append(kindList.Items, converted)
+			}
+			if listArgs.Fields != nil {
+				filteredItems, err := presenters.SliceFilter(listArgs.Fields, kindList.Items)
+				if err != nil {
+					return nil, err
+				}
+				return filteredItems, nil
+			}
+			return kindList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+func (h customKindHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			name := mux.Vars(r)["name"]
+			ctx := r.Context()
+			kind, err := h.customKind.Get(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentCustomKind(kind), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h customKindHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			name := mux.Vars(r)["name"]
+			ctx := r.Context()
+			err := h.customKind.Delete(ctx, name)
+			if err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+var _ RestHandler = customResourceHandler{}
+
+// customResourceHandler serves the resources of every custom kind, the kind named by the name of the path
+type customResourceHandler struct {
+	customResource services.CustomResourceService
+}
+
+func NewCustomResourceHandler(customResource services.CustomResourceService) *customResourceHandler {
+	return &customResourceHandler{
+		customResource: customResource,
+	}
+}
+
+func (h customResourceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var customResource openapi.CustomResource
+	cfg := &handlerConfig{
+		&customResource,
+		[]validate{
+			validateEmpty(&customResource, "Id", "id"),
+			validateNotNil(&customResource, "Properties", "properties"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			kind := mux.Vars(r)["name"]
+			resource, err := h.customResource.Create(ctx, presenters.ConvertCustomResource(kind, customResource))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentCustomResource(resource), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h customResourceHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	var patch openapi.CustomResourcePatchRequest
+
+	cfg := &handlerConfig{
+		&patch,
+		[]validate{
+			validateNotNil(&patch, "Properties", "properties"),
+		},
+		func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			vars := mux.Vars(r)
+			resource, err := h.customResource.Replace(ctx, presenters.ConvertCustomResource(vars["name"], openapi.CustomResource{
+				Id:         openapi.PtrString(vars["id"]),
+				Properties: patch.Properties,
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return presenters.PresentCustomResource(resource), nil
+		},
+		handleError,
+	}
+
+	handle(w, r, cfg, http.StatusOK)
+}
+
+func (h customResourceHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			kind := mux.Vars(r)["name"]
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			resources, paging, err := h.customResource.List(ctx, kind, listArgs)
+			if err != nil {
+				return nil, err
+			}
+			resourceList := openapi.CustomResourceList{
+				Kind:  "CustomResourceList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.CustomResource{},
+			}
+
+			for _, resource := range resources {
+				converted := presenters.PresentCustomResource(resource)
+				resourceList.Items = append(resourceList.Items, converted)
+			}
+			if listArgs.Fields != nil {
+				filteredItems, err := presenters.SliceFilter(listArgs.Fields, resourceList.Items)
+				if err != nil {
+					return nil, err
+				}
+				return filteredItems, nil
+			}
+			return resourceList, nil
+		},
+	}
+
+	handleList(w, r, cfg)
+}
+
+func (h customResourceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			vars := mux.Vars(r)
+			ctx := r.Context()
+			resource, err := h.customResource.Get(ctx, vars["name"], vars["id"])
+			if err != nil {
+				return nil, err
+			}
+
+			return presenters.PresentCustomResource(resource), nil
+		},
+	}
+
+	handleGet(w, r, cfg)
+}
+
+func (h customResourceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			vars := mux.Vars(r)
+			ctx := r.Context()
+			err := h.customResource.Delete(ctx, vars["name"], vars["id"])
+			if err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handleDelete(w, r, cfg, http.StatusNoContent)
+}
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services/mocks"
+)
+
+func TestDinosaurHandlerGet(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	genericService := mocks.NewMockGenericService(ctrl)
+	handler := NewDinosaurHandler(dinosaurService, genericService)
+
+	dinosaur := &api.Dinosaur{Species: "Triceratops"}
+	dinosaur.ID = "123"
+	dinosaurService.EXPECT().Get(gomock.Any(), "123").Return(dinosaur, nil)
+
+	w := httptest.NewRecorder()
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/dinosaurs/123", nil), map[string]string{"id": "123"})
+	handler.Get(w, r)
+	Expect(w.Code).To(Equal(http.StatusOK))
+
+	var body openapi.Dinosaur
+	Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+	Expect(*body.Id).To(Equal("123"))
+	Expect(*body.Species).To(Equal("Triceratops"))
+}
+
+func TestDinosaurHandlerGetNotFound(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	handler := NewDinosaurHandler(dinosaurService, mocks.NewMockGenericService(ctrl))
+
+	dinosaurService.EXPECT().Get(gomock.Any(), "404").Return(nil, errors.NotFound("Dinosaur with id='404' not found"))
+
+	w := httptest.NewRecorder()
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodGet, "/dinosaurs/404", nil), map[string]string{"id": "404"})
+	handler.Get(w, r)
+	Expect(w.Code).To(Equal(http.StatusNotFound))
+}
+
+func TestDinosaurHandlerDelete(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	handler := NewDinosaurHandler(dinosaurService, mocks.NewMockGenericService(ctrl))
+
+	dinosaurService.EXPECT().Delete(gomock.Any(), "123").Return(nil).Times(1)
+
+	w := httptest.NewRecorder()
+	r := mux.SetURLVars(httptest.NewRequest(http.MethodDelete, "/dinosaurs/123", nil), map[string]string{"id": "123"})
+	handler.Delete(w, r)
+	Expect(w.Code).To(Equal(http.StatusNoContent))
+}
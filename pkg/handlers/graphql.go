@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/graphql"
+)
+
+type graphqlHandler struct {
+	schema *graphql.Schema
+}
+
+func NewGraphQLHandler(schema *graphql.Schema) *graphqlHandler {
+	return &graphqlHandler{schema: schema}
+}
+
+// Query runs the query POSTed as a JSON graphql.Request, or given by the query, operationName and
+// variables parameters of a GET. Errors of the query are in the body of its 200 response.
+func (h graphqlHandler) Query(w http.ResponseWriter, r *http.Request) {
+	var request graphql.Request
+	if r.Method == http.MethodGet {
+		params := r.URL.Query()
+		request.Query = params.Get("query")
+		request.OperationName = params.Get("operationName")
+		if variables := params.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				handleError(r.Context(), w, errors.MalformedRequest("Invalid variables: %s", err))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		handleError(r.Context(), w, errors.MalformedRequest("Invalid request format: %s", err))
+		return
+	}
+	if request.Query == "" {
+		handleError(r.Context(), w, errors.Validation("query is required"))
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, h.schema.Execute(r.Context(), request))
+}
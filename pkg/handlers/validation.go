@@ -66,3 +66,12 @@ func validateDinosaurPatch(patch *openapi.DinosaurPatchRequest) validate {
 		return nil
 	}
 }
+
+func validateNotNil(i interface{}, fieldName string, field string) validate {
+	return func() *errors.ServiceError {
+		if reflect.ValueOf(i).Elem().FieldByName(fieldName).IsNil() {
+			return errors.Validation("%s is required", field)
+		}
+		return nil
+	}
+}
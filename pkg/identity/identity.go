@@ -0,0 +1,26 @@
+// Package identity defines the names the service is known by to its clients. The API paths, error
+// codes and error hrefs all derive from these, and `trex init` renames them along with trex.yaml.
+package identity
+
+const (
+	// ID identifies the API in its metadata
+	ID = "ocm_example"
+
+	// APIPrefix is the base path of the REST API
+	APIPrefix = "/api/ocm-example-service"
+
+	// APIVersion is the current version of the REST API
+	APIVersion = "v1"
+
+	// BasePath is the path of the current version of the REST API
+	BasePath = APIPrefix + "/" + APIVersion
+
+	// ErrorPrefix starts every error code
+	// Example:
+	//   ErrorPrefix = "OCM-EXAMPLE"
+	//   results in: OCM-EXAMPLE-1
+	ErrorPrefix = "OCM-EXAMPLE"
+
+	// ErrorsPath is the path of the API's error catalog
+	ErrorsPath = BasePath + "/errors"
+)
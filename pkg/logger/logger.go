@@ -57,6 +57,10 @@ func (l *logger) prepareLogPrefix(message string, extra extra) string {
 		prefix = fmt.Sprintf("[accountID=%s]%s", l.accountID, prefix)
 	}
 
+	if parentOpID, ok := l.context.Value(ParentOpIDKey).(string); ok {
+		prefix = fmt.Sprintf("[parent_opid=%s]%s", parentOpID, prefix)
+	}
+
 	if opid, ok := l.context.Value(OpIDKey).(string); ok {
 		prefix = fmt.Sprintf("[opid=%s]%s", opid, prefix)
 	}
@@ -81,6 +85,10 @@ func (l *logger) prepareLogPrefixf(format string, args ...interface{}) string {
 		prefix = fmt.Sprintf("[accountID=%s]%s", l.accountID, prefix)
 	}
 
+	if parentOpID, ok := l.context.Value(ParentOpIDKey).(string); ok {
+		prefix = fmt.Sprintf("[parent_opid=%s]%s", parentOpID, prefix)
+	}
+
 	if opid, ok := l.context.Value(OpIDKey).(string); ok {
 		prefix = fmt.Sprintf("[opid=%s]%s", opid, prefix)
 	}
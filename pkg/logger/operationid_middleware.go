@@ -3,6 +3,8 @@ package logger
 import (
 	"context"
 	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/getsentry/sentry-go"
 	"github.com/segmentio/ksuid"
@@ -13,10 +15,31 @@ type OperationIDKey string
 const OpIDKey OperationIDKey = "opID"
 const OpIDHeader OperationIDKey = "X-Operation-ID"
 
+// ParentOpIDKey holds the operation ID of the caller of a request, see ParentOperationID
+const ParentOpIDKey OperationIDKey = "parentOpID"
+
+// CallerOpIDHeader is the header of the outbound requests holding the operation ID of the service making them,
+// while their X-Operation-ID holds that of the operation they're part of, see OperationIDTransport
+const CallerOpIDHeader = "X-Caller-Operation-ID"
+
+// TraceParentHeader is the W3C trace context header, whose trace ID is the parent operation ID of the
+// requests without an X-Operation-ID
+const TraceParentHeader = "traceparent"
+
+// maxOperationIDLength caps the operation IDs honored, which end up in every log line
+const maxOperationIDLength = 64
+
+var (
+	operationIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+	traceParentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+	emptyTraceID       = strings.Repeat("0", 32)
+)
+
 // Middleware wraps the given HTTP handler so that the details of the request are sent to the log.
 func OperationIDMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := WithOpID(r.Context())
+		ctx := WithParentOpID(r.Context(), ParentOperationID(r.Header.Get(string(OpIDHeader)), r.Header.Get(TraceParentHeader)))
+		ctx = WithOpID(ctx)
 
 		opID, ok := ctx.Value(OpIDKey).(string)
 		if ok && len(opID) > 0 {
@@ -26,7 +49,7 @@ func OperationIDMiddleware(handler http.Handler) http.Handler {
 		// Add operation ID to sentry context
 		if hub := sentry.GetHubFromContext(ctx); hub != nil {
 			hub.ConfigureScope(func(scope *sentry.Scope) {
-				scope.SetTag("operation_id", opID)
+				SetSentryTags(ctx, scope)
 			})
 		}
 
@@ -34,6 +57,14 @@ func OperationIDMiddleware(handler http.Handler) http.Handler {
 	})
 }
 
+// SetSentryTags tags the events of scope with the operation ID of ctx, and that of its caller if known
+func SetSentryTags(ctx context.Context, scope *sentry.Scope) {
+	scope.SetTag("operation_id", GetOperationID(ctx))
+	if parentOpID := GetParentOperationID(ctx); parentOpID != "" {
+		scope.SetTag("parent_operation_id", parentOpID)
+	}
+}
+
 func WithOpID(ctx context.Context) context.Context {
 	if ctx.Value(OpIDKey) != nil {
 		return ctx
@@ -49,3 +80,63 @@ func GetOperationID(ctx context.Context) string {
 	}
 	return ""
 }
+
+// ParentOperationID returns the operation ID a caller sent along with its request, either as its
+// X-Operation-ID header or as the trace ID of its traceparent header, or "" when neither is valid. The
+// request still gets an operation ID of its own, since callers may send the same one with many requests.
+func ParentOperationID(operationID, traceParent string) string {
+	operationID = strings.TrimSpace(operationID)
+	if len(operationID) <= maxOperationIDLength && operationIDPattern.MatchString(operationID) {
+		return operationID
+	}
+	if match := traceParentPattern.FindStringSubmatch(strings.TrimSpace(traceParent)); match != nil && match[1] != emptyTraceID {
+		return match[1]
+	}
+	return ""
+}
+
+// WithParentOpID returns a context holding the operation ID of the caller of a request, when it's known
+func WithParentOpID(ctx context.Context, parentOpID string) context.Context {
+	if parentOpID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, ParentOpIDKey, parentOpID)
+}
+
+// GetParentOperationID returns the operation ID of the caller of the request of ctx, if it sent one
+func GetParentOperationID(ctx context.Context) string {
+	if parentOpID, ok := ctx.Value(ParentOpIDKey).(string); ok {
+		return parentOpID
+	}
+	return ""
+}
+
+// OperationIDTransport wraps the transport of outbound requests, so the services called can log the operation
+// each request is part of as their parent. Its X-Operation-ID is the parent operation ID of the context of the
+// request, passed on from hop to hop, or the context's own operation ID when it has no parent. The context's
+// operation ID is sent as the X-Caller-Operation-ID.
+func OperationIDTransport(transport http.RoundTripper) http.RoundTripper {
+	return operationIDTransport{transport: transport}
+}
+
+type operationIDTransport struct {
+	transport http.RoundTripper
+}
+
+func (t operationIDTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+	opID := GetOperationID(ctx)
+	if opID == "" || request.Header.Get(string(OpIDHeader)) != "" {
+		return t.transport.RoundTrip(request)
+	}
+
+	operationID := opID
+	if parentOpID := GetParentOperationID(ctx); parentOpID != "" {
+		operationID = parentOpID
+	}
+	// round trippers mustn't change the requests they're given
+	request = request.Clone(ctx)
+	request.Header.Set(string(OpIDHeader), operationID)
+	request.Header.Set(CallerOpIDHeader, opID)
+	return t.transport.RoundTrip(request)
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParentOperationID(t *testing.T) {
+	RegisterTestingT(t)
+
+	traceParent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	Expect(ParentOperationID("2Xnb0dyBSVZmWDQ5Yv2U3SyOYrC", "")).To(Equal("2Xnb0dyBSVZmWDQ5Yv2U3SyOYrC"))
+	// the operation ID is preferred to the trace ID
+	Expect(ParentOperationID("caller-1", traceParent)).To(Equal("caller-1"))
+	Expect(ParentOperationID("", traceParent)).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+
+	// invalid operation IDs aren't honored, nor invalid trace IDs
+	Expect(ParentOperationID("[opid=forged]", "")).To(BeEmpty())
+	Expect(ParentOperationID("a\nb", "")).To(BeEmpty())
+	Expect(ParentOperationID(strings.Repeat("a", maxOperationIDLength+1), traceParent)).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+	Expect(ParentOperationID("", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")).To(BeEmpty())
+	Expect(ParentOperationID("", "00-4bf92f3577b34da6-00f067aa0ba902b7-01")).To(BeEmpty())
+	Expect(ParentOperationID("", "")).To(BeEmpty())
+}
+
+func TestOperationIDMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	var opID, parentOpID string
+	handler := OperationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		opID, parentOpID = GetOperationID(r.Context()), GetParentOperationID(r.Context())
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/ocm-example-service/v1/dinosaurs", nil)
+	request.Header.Set(string(OpIDHeader), "caller-1")
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+
+	// the request gets an operation ID of its own, returned to the caller
+	Expect(parentOpID).To(Equal("caller-1"))
+	Expect(opID).NotTo(BeEmpty())
+	Expect(opID).NotTo(Equal(parentOpID))
+	Expect(response.Header().Get(string(OpIDHeader))).To(Equal(opID))
+}
+
+func TestOperationIDTransport(t *testing.T) {
+	RegisterTestingT(t)
+
+	var received, receivedCaller string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, receivedCaller = r.Header.Get(string(OpIDHeader)), r.Header.Get(CallerOpIDHeader)
+	}))
+	defer server.Close()
+	client := &http.Client{Transport: OperationIDTransport(http.DefaultTransport)}
+
+	ctx := WithOpID(httptest.NewRequest(http.MethodGet, "/", nil).Context())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	Expect(err).NotTo(HaveOccurred())
+	response, err := client.Do(request)
+	Expect(err).NotTo(HaveOccurred())
+	response.Body.Close()
+
+	Expect(received).To(Equal(GetOperationID(ctx)))
+	Expect(receivedCaller).To(Equal(GetOperationID(ctx)))
+	// the request given to the transport is left as is
+	Expect(request.Header.Get(string(OpIDHeader))).To(BeEmpty())
+}
+
+func TestOperationIDTransportHops(t *testing.T) {
+	RegisterTestingT(t)
+	client := &http.Client{Transport: OperationIDTransport(http.DefaultTransport)}
+
+	// the second hop, logging its parent
+	var parentOpID, caller string
+	second := httptest.NewServer(OperationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parentOpID, caller = GetParentOperationID(r.Context()), r.Header.Get(CallerOpIDHeader)
+	})))
+	defer second.Close()
+
+	// the first hop, calling the second
+	first := httptest.NewServer(OperationIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request, err := http.NewRequestWithContext(r.Context(), http.MethodGet, second.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+	})))
+	defer first.Close()
+
+	call := func(operationID string) string {
+		request, err := http.NewRequest(http.MethodGet, first.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if operationID != "" {
+			request.Header.Set(string(OpIDHeader), operationID)
+		}
+		response, err := http.DefaultClient.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		return response.Header.Get(string(OpIDHeader))
+	}
+
+	// the operation ID of the caller reaches the second hop, along with that of the first
+	firstOpID := call("caller-1")
+	Expect(parentOpID).To(Equal("caller-1"))
+	Expect(caller).To(Equal(firstOpID))
+
+	// without one, the operation starts at the first hop
+	firstOpID = call("")
+	Expect(parentOpID).To(Equal(firstOpID))
+	Expect(caller).To(Equal(firstOpID))
+}
@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+)
+
+var _ Publisher = &FilePublisher{}
+
+// FilePublisher writes the events to a file or stdout as JSON lines, for development
+type FilePublisher struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+// NewFilePublisher appends the events to the file at path, or writes them to stdout if path is "-"
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	if path == "-" {
+		return NewWriterPublisher("stdout", os.Stdout), nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterPublisher("file:"+path, f), nil
+}
+
+// NewWriterPublisher writes the events to w
+func NewWriterPublisher(name string, w io.Writer) *FilePublisher {
+	return &FilePublisher{name: name, w: w}
+}
+
+func (p *FilePublisher) Name() string {
+	return p.name
+}
+
+func (p *FilePublisher) Publish(ctx context.Context, events api.EventList) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, event := range events {
+		line, err := NewMessage(event).Marshal()
+		if err != nil {
+			return err
+		}
+		if _, err := p.w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("unable to write event %s: %s", event.ID, err)
+		}
+	}
+	// the events are published once they are on disk
+	if f, ok := p.w.(*os.File); ok && f != os.Stdout {
+		return f.Sync()
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package outbox
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFilePublisher(t *testing.T) {
+	RegisterTestingT(t)
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	publisher, err := NewFilePublisher(path)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(publisher.Name()).To(Equal("file:" + path))
+	Expect(publisher.Publish(context.Background(), testEvents("1", "2"))).To(Succeed())
+
+	// a new publisher appends to the file
+	publisher, err = NewFilePublisher(path)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(publisher.Publish(context.Background(), testEvents("3"))).To(Succeed())
+
+	content, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	Expect(lines).To(HaveLen(3))
+	Expect(lines[0]).To(Equal(`{"id":"1","source":"Dinosaurs","source_id":"dinosaur-1","event_type":"Create","created_at":"2023-03-07T15:04:05Z"}`))
+
+	stdout, err := NewFilePublisher("-")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(stdout.Name()).To(Equal("stdout"))
+}
@@ -0,0 +1,324 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+)
+
+var _ Publisher = &KafkaPublisher{}
+
+// KafkaPublisher produces the events to a Kafka topic, speaking the Kafka protocol to the brokers itself.
+//
+// Every event is produced to the same partition, so consumers see them in the order of the outbox. The
+// record key is the ID of the resource the event is about, the value its Message as JSON, and the event
+// type and source are in the record headers. Produce requests wait for all in-sync replicas.
+type KafkaPublisher struct {
+	brokers   []string
+	topic     string
+	partition int32
+	clientID  string
+	timeout   time.Duration
+
+	mu            sync.Mutex
+	conn          *kafkaConn
+	correlationID int32
+}
+
+// kafkaConn is a connection to a broker, with the versions of the requests agreed with it
+type kafkaConn struct {
+	net.Conn
+	versions kafkaVersions
+}
+
+func NewKafkaPublisher(brokers []string, topic, clientID string, timeout time.Duration) *KafkaPublisher {
+	return &KafkaPublisher{
+		brokers:  brokers,
+		topic:    topic,
+		clientID: clientID,
+		timeout:  timeout,
+	}
+}
+
+func (p *KafkaPublisher) Name() string {
+	return "kafka:" + p.topic
+}
+
+func (p *KafkaPublisher) Publish(ctx context.Context, events api.EventList) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	records := make([]kafkaRecord, 0, len(events))
+	for _, event := range events {
+		value, err := NewMessage(event).Marshal()
+		if err != nil {
+			return err
+		}
+		records = append(records, kafkaRecord{
+			Key:   []byte(event.SourceID),
+			Value: value,
+			Headers: [][2][]byte{
+				{[]byte("event_type"), []byte(event.EventType)},
+				{[]byte("source"), []byte(event.Source)},
+			},
+			Timestamp: event.CreatedAt.UnixMilli(),
+		})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		conn, err := p.connectLeader(ctx)
+		if err != nil {
+			return err
+		}
+		p.conn = conn
+	}
+	if err := p.produce(ctx, encodeRecordBatch(records)); err != nil {
+		// the leader may have moved, look it up again on the next publish
+		p.conn.Close()
+		p.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the partition leader
+func (p *KafkaPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn = nil
+	return err
+}
+
+// connectLeader asks the brokers for the leader of the partition and connects to it
+func (p *KafkaPublisher) connectLeader(ctx context.Context) (*kafkaConn, error) {
+	var lastErr error
+	for _, broker := range p.brokers {
+		conn, err := p.dial(ctx, broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		leader, err := p.metadata(ctx, conn)
+		if err != nil {
+			conn.Close()
+			lastErr = fmt.Errorf("unable to look up the leader of %s/%d on %s: %s", p.topic, p.partition, broker, err)
+			continue
+		}
+		if leader == broker {
+			return conn, nil
+		}
+		conn.Close()
+		return p.dial(ctx, leader)
+	}
+	return nil, lastErr
+}
+
+// dial connects to the broker at address, and agrees with it on the versions of the requests
+func (p *KafkaPublisher) dial(ctx context.Context, address string) (*kafkaConn, error) {
+	dialer := net.Dialer{Timeout: p.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to kafka broker %s: %s", address, err)
+	}
+	versions, err := p.apiVersions(ctx, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to agree on the protocol versions with kafka broker %s: %s", address, err)
+	}
+	return &kafkaConn{Conn: conn, versions: versions}, nil
+}
+
+// apiVersions asks the broker the versions of the requests it supports, and picks the ones to use
+func (p *KafkaPublisher) apiVersions(ctx context.Context, conn net.Conn) (kafkaVersions, error) {
+	// brokers answer ApiVersions v0 whatever the versions they support
+	r, err := p.roundTrip(ctx, conn, kafkaApiVersions.key, kafkaApiVersions.max, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	errorCode := r.int16()
+	supported := map[int16][2]int16{}
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		key := r.int16()
+		supported[key] = [2]int16{r.int16(), r.int16()}
+	}
+	if r.err != nil {
+		return nil, fmt.Errorf("malformed api versions response: %s", r.err)
+	}
+	if errorCode != 0 {
+		return nil, kafkaError(errorCode)
+	}
+	return negotiateKafkaVersions(supported)
+}
+
+// metadata returns the address of the broker leading the partition
+func (p *KafkaPublisher) metadata(ctx context.Context, conn *kafkaConn) (string, error) {
+	version := conn.versions[kafkaMetadata.key]
+
+	var request kafkaWriter
+	request.int32(1)
+	request.string(p.topic)
+	if version >= 4 {
+		request.int8(1) // allow auto topic creation, as the broker is configured to
+	}
+	if version >= 8 {
+		request.int8(0) // include cluster authorized operations
+		request.int8(0) // include topic authorized operations
+	}
+
+	r, err := p.roundTrip(ctx, conn, kafkaMetadata.key, version, request.Bytes())
+	if err != nil {
+		return "", err
+	}
+
+	if version >= 3 {
+		r.int32() // throttle time
+	}
+	brokers := map[int32]string{}
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		nodeID := r.int32()
+		host := r.string()
+		port := r.int32()
+		r.string() // rack
+		brokers[nodeID] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	if version >= 2 {
+		r.string() // cluster ID
+	}
+	r.int32() // controller ID
+
+	for topics := r.int32(); topics > 0 && r.err == nil; topics-- {
+		topicErr := r.int16()
+		name := r.string()
+		r.int8() // is internal
+		for partitions := r.int32(); partitions > 0 && r.err == nil; partitions-- {
+			partitionErr := r.int16()
+			index := r.int32()
+			leader := r.int32()
+			if version >= 7 {
+				r.int32() // leader epoch
+			}
+			for replicas := r.int32(); replicas > 0 && r.err == nil; replicas-- {
+				r.int32()
+			}
+			for isr := r.int32(); isr > 0 && r.err == nil; isr-- {
+				r.int32()
+			}
+			if version >= 5 {
+				for offline := r.int32(); offline > 0 && r.err == nil; offline-- {
+					r.int32()
+				}
+			}
+			if name != p.topic || index != p.partition || r.err != nil {
+				continue
+			}
+			if topicErr != 0 {
+				return "", kafkaError(topicErr)
+			}
+			if partitionErr != 0 {
+				return "", kafkaError(partitionErr)
+			}
+			address, found := brokers[leader]
+			if !found {
+				return "", kafkaError(5)
+			}
+			return address, nil
+		}
+		if version >= 8 {
+			r.int32() // topic authorized operations
+		}
+		if name == p.topic && topicErr != 0 && r.err == nil {
+			return "", kafkaError(topicErr)
+		}
+	}
+	if r.err != nil {
+		return "", fmt.Errorf("malformed metadata response: %s", r.err)
+	}
+	return "", kafkaError(3)
+}
+
+// produce produces a record batch to the partition on its leader
+func (p *KafkaPublisher) produce(ctx context.Context, batch []byte) error {
+	var request kafkaWriter
+	request.nullString() // transactional ID
+	request.int16(kafkaAcksAll)
+	request.int32(int32(p.timeout.Milliseconds()))
+	request.int32(1)
+	request.string(p.topic)
+	request.int32(1)
+	request.int32(p.partition)
+	request.bytes(batch)
+
+	version := p.conn.versions[kafkaProduce.key]
+	r, err := p.roundTrip(ctx, p.conn, kafkaProduce.key, version, request.Bytes())
+	if err != nil {
+		return err
+	}
+
+	for topics := r.int32(); topics > 0 && r.err == nil; topics-- {
+		r.string() // name
+		for partitions := r.int32(); partitions > 0 && r.err == nil; partitions-- {
+			r.int32() // index
+			errorCode := r.int16()
+			r.int64() // base offset
+			r.int64() // log append time
+			if version >= 5 {
+				r.int64() // log start offset
+			}
+			if version >= 8 {
+				for recordErrors := r.int32(); recordErrors > 0 && r.err == nil; recordErrors-- {
+					r.int32()  // batch index
+					r.string() // batch index error message
+				}
+				r.string() // error message
+			}
+			if errorCode != 0 && r.err == nil {
+				return fmt.Errorf("unable to produce to %s/%d: %w", p.topic, p.partition, kafkaError(errorCode))
+			}
+		}
+	}
+	if r.err != nil {
+		return fmt.Errorf("malformed produce response: %s", r.err)
+	}
+	return nil
+}
+
+// roundTrip sends a request on conn and reads its response, returning a reader of the response body
+func (p *KafkaPublisher) roundTrip(ctx context.Context, conn net.Conn, apiKey, apiVersion int16, body []byte) (*kafkaReader, error) {
+	deadline := time.Now().Add(p.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	p.correlationID++
+	if _, err := conn.Write(kafkaRequest(apiKey, apiVersion, p.correlationID, p.clientID, body)); err != nil {
+		return nil, err
+	}
+	frame, err := readKafkaFrame(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &kafkaReader{b: frame}
+	if correlationID := r.int32(); r.err == nil && correlationID != p.correlationID {
+		return nil, fmt.Errorf("kafka response to request %d received for request %d", correlationID, p.correlationID)
+	}
+	return r, r.err
+}
@@ -0,0 +1,237 @@
+package outbox
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// The subset of the Kafka protocol the KafkaPublisher speaks, see https://kafka.apache.org/protocol.
+// The publisher speaks a range of the non-flexible versions of each request, and uses the highest one the
+// broker supports too, which it asks with ApiVersions once connected. Brokers since Kafka 0.11 support a
+// version of each, Kafka 4.0 dropped the versions before Kafka 2.1's (KIP-896) but still supports the
+// highest ones.
+var (
+	kafkaProduce     = kafkaAPI{key: 0, name: "Produce", min: 3, max: 8}
+	kafkaMetadata    = kafkaAPI{key: 3, name: "Metadata", min: 1, max: 8}
+	kafkaApiVersions = kafkaAPI{key: 18, name: "ApiVersions", min: 0, max: 0}
+)
+
+// kafkaAcksAll waits for every in-sync replica to have the records before a produce succeeds
+const kafkaAcksAll int16 = -1
+
+// kafkaAPI is a request of the protocol, with the range of its versions the publisher speaks
+type kafkaAPI struct {
+	key      int16
+	name     string
+	min, max int16
+}
+
+// kafkaVersions are the versions of the requests agreed with a broker, by API key
+type kafkaVersions map[int16]int16
+
+// negotiateKafkaVersions picks the highest version of each request the publisher speaks that the broker
+// supports, from the versions ranges of the broker's ApiVersions response
+func negotiateKafkaVersions(supported map[int16][2]int16) (kafkaVersions, error) {
+	versions := kafkaVersions{}
+	for _, api := range []kafkaAPI{kafkaMetadata, kafkaProduce} {
+		brokerRange, found := supported[api.key]
+		if !found {
+			return nil, fmt.Errorf("the broker doesn't support %s requests", api.name)
+		}
+		if brokerRange[1] < api.min || brokerRange[0] > api.max {
+			return nil, fmt.Errorf("the broker supports %s v%d to v%d, the publisher only v%d to v%d",
+				api.name, brokerRange[0], brokerRange[1], api.min, api.max)
+		}
+		versions[api.key] = min(brokerRange[1], api.max)
+	}
+	return versions, nil
+}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+// kafkaError is an error code returned by a broker
+type kafkaError int16
+
+func (e kafkaError) Error() string {
+	switch e {
+	case 3:
+		return "kafka error 3: unknown topic or partition"
+	case 5:
+		return "kafka error 5: leader not available"
+	case 6:
+		return "kafka error 6: not leader or follower"
+	case 7:
+		return "kafka error 7: request timed out"
+	default:
+		return fmt.Sprintf("kafka error %d", int16(e))
+	}
+}
+
+// kafkaWriter encodes the primitive types of the protocol
+type kafkaWriter struct {
+	bytes.Buffer
+}
+
+func (w *kafkaWriter) int8(v int8) {
+	w.WriteByte(byte(v))
+}
+
+func (w *kafkaWriter) int16(v int16) {
+	w.Write(binary.BigEndian.AppendUint16(nil, uint16(v)))
+}
+
+func (w *kafkaWriter) int32(v int32) {
+	w.Write(binary.BigEndian.AppendUint32(nil, uint32(v)))
+}
+
+func (w *kafkaWriter) int64(v int64) {
+	w.Write(binary.BigEndian.AppendUint64(nil, uint64(v)))
+}
+
+func (w *kafkaWriter) string(s string) {
+	w.int16(int16(len(s)))
+	w.WriteString(s)
+}
+
+func (w *kafkaWriter) nullString() {
+	w.int16(-1)
+}
+
+func (w *kafkaWriter) bytes(b []byte) {
+	w.int32(int32(len(b)))
+	w.Write(b)
+}
+
+// varint is the zigzag encoded variable length integer of the record format
+func (w *kafkaWriter) varint(v int64) {
+	w.Write(binary.AppendVarint(nil, v))
+}
+
+func (w *kafkaWriter) varbytes(b []byte) {
+	w.varint(int64(len(b)))
+	w.Write(b)
+}
+
+// kafkaReader decodes the primitive types of the protocol, remembering the first error
+type kafkaReader struct {
+	b   []byte
+	err error
+}
+
+func (r *kafkaReader) next(n int) []byte {
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	if n < 0 || len(r.b) < n {
+		r.err = io.ErrUnexpectedEOF
+		return make([]byte, max(n, 0))
+	}
+	b := r.b[:n]
+	r.b = r.b[n:]
+	return b
+}
+
+func (r *kafkaReader) int8() int8 {
+	return int8(r.next(1)[0])
+}
+
+func (r *kafkaReader) int16() int16 {
+	return int16(binary.BigEndian.Uint16(r.next(2)))
+}
+
+func (r *kafkaReader) int32() int32 {
+	return int32(binary.BigEndian.Uint32(r.next(4)))
+}
+
+func (r *kafkaReader) int64() int64 {
+	return int64(binary.BigEndian.Uint64(r.next(8)))
+}
+
+func (r *kafkaReader) string() string {
+	n := r.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(r.next(int(n)))
+}
+
+// kafkaRequest frames a request: its size, the request header, then the body
+func kafkaRequest(apiKey, apiVersion int16, correlationID int32, clientID string, body []byte) []byte {
+	var header kafkaWriter
+	header.int16(apiKey)
+	header.int16(apiVersion)
+	header.int32(correlationID)
+	header.string(clientID)
+
+	var request kafkaWriter
+	request.int32(int32(header.Len() + len(body)))
+	request.Write(header.Bytes())
+	request.Write(body)
+	return request.Bytes()
+}
+
+// readKafkaFrame reads a size prefixed request or response
+func readKafkaFrame(r io.Reader) ([]byte, error) {
+	var size [4]byte
+	if _, err := io.ReadFull(r, size[:]); err != nil {
+		return nil, err
+	}
+	frame := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// kafkaRecord is a record of a record batch
+type kafkaRecord struct {
+	Key       []byte
+	Value     []byte
+	Headers   [][2][]byte
+	Timestamp int64 // milliseconds since the epoch
+}
+
+// encodeRecordBatch encodes records as a v2 record batch, the message format of Kafka 0.11 and later
+func encodeRecordBatch(records []kafkaRecord) []byte {
+	first, last := records[0].Timestamp, records[0].Timestamp
+	for _, record := range records {
+		last = max(last, record.Timestamp)
+	}
+
+	// everything from the attributes on is covered by the CRC
+	var body kafkaWriter
+	body.int16(0) // attributes: no compression, create time timestamps, not transactional
+	body.int32(int32(len(records) - 1))
+	body.int64(first)
+	body.int64(last)
+	body.int64(-1) // producer ID, the producer isn't idempotent
+	body.int16(-1) // producer epoch
+	body.int32(-1) // base sequence
+	body.int32(int32(len(records)))
+	for i, record := range records {
+		var r kafkaWriter
+		r.int8(0) // attributes
+		r.varint(record.Timestamp - first)
+		r.varint(int64(i))
+		r.varbytes(record.Key)
+		r.varbytes(record.Value)
+		r.varint(int64(len(record.Headers)))
+		for _, header := range record.Headers {
+			r.varbytes(header[0])
+			r.varbytes(header[1])
+		}
+		body.varbytes(r.Bytes())
+	}
+
+	var batch kafkaWriter
+	batch.int64(0) // base offset, assigned by the broker
+	batch.int32(int32(4 + 1 + 4 + body.Len()))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(2)   // magic
+	batch.int32(int32(crc32.Checksum(body.Bytes(), castagnoli)))
+	batch.Write(body.Bytes())
+	return batch.Bytes()
+}
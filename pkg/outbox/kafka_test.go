@@ -0,0 +1,458 @@
+package outbox
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"maps"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+)
+
+// kafka4Versions are the versions of the requests Kafka 4.0 supports
+var kafka4Versions = map[int16][2]int16{
+	kafkaProduce.key:     {3, 12},
+	kafkaMetadata.key:    {4, 13},
+	kafkaApiVersions.key: {0, 4},
+}
+
+// standInBroker is a local stand-in for a Kafka broker. It answers metadata requests for any topic with a
+// single partition, led by itself or by leader, and keeps the records produced to it. Like a broker, it
+// closes the connections making requests of versions it doesn't support.
+type standInBroker struct {
+	listener net.Listener
+	leader   *standInBroker
+	versions map[int16][2]int16
+
+	mu        sync.Mutex
+	records   []kafkaRecord
+	failNext  int16
+	requested kafkaVersions
+}
+
+func newStandInBroker(t *testing.T, leader *standInBroker) *standInBroker {
+	return newStandInBrokerOf(t, leader, kafka4Versions)
+}
+
+// newStandInBrokerOf starts a stand-in broker supporting versions
+func newStandInBrokerOf(t *testing.T, leader *standInBroker, versions map[int16][2]int16) *standInBroker {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %s", err)
+	}
+	b := &standInBroker{listener: listener, leader: leader, versions: versions, requested: kafkaVersions{}}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go b.serve(conn)
+		}
+	}()
+	return b
+}
+
+func (b *standInBroker) addr() string {
+	return b.listener.Addr().String()
+}
+
+// fail fails the next produce request with errorCode
+func (b *standInBroker) fail(errorCode int16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failNext = errorCode
+}
+
+// requestedVersions returns the last version of each request made to the broker
+func (b *standInBroker) requestedVersions() kafkaVersions {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return maps.Clone(b.requested)
+}
+
+func (b *standInBroker) produced() []kafkaRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]kafkaRecord{}, b.records...)
+}
+
+func (b *standInBroker) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		frame, err := readKafkaFrame(conn)
+		if err != nil {
+			return
+		}
+		r := &kafkaReader{b: frame}
+		apiKey, apiVersion, correlationID := r.int16(), r.int16(), r.int32()
+		r.string() // client ID
+
+		supported, found := b.versions[apiKey]
+		if !found || apiVersion < supported[0] || apiVersion > supported[1] {
+			return
+		}
+		b.mu.Lock()
+		b.requested[apiKey] = apiVersion
+		b.mu.Unlock()
+
+		var response kafkaWriter
+		response.int32(correlationID)
+		switch apiKey {
+		case kafkaApiVersions.key:
+			b.apiVersions(&response)
+		case kafkaMetadata.key:
+			b.metadata(r, apiVersion, &response)
+		case kafkaProduce.key:
+			b.produce(r, apiVersion, &response)
+		default:
+			return
+		}
+		if r.err != nil {
+			return
+		}
+
+		var out kafkaWriter
+		out.bytes(response.Bytes())
+		if _, err := conn.Write(out.Bytes()); err != nil {
+			return
+		}
+	}
+}
+
+func (b *standInBroker) apiVersions(w *kafkaWriter) {
+	w.int16(0)
+	w.int32(int32(len(b.versions)))
+	for key, supported := range b.versions {
+		w.int16(key)
+		w.int16(supported[0])
+		w.int16(supported[1])
+	}
+}
+
+func (b *standInBroker) metadata(r *kafkaReader, version int16, w *kafkaWriter) {
+	topics := []string{}
+	for n := r.int32(); n > 0 && r.err == nil; n-- {
+		topics = append(topics, r.string())
+	}
+	if version >= 4 {
+		r.int8() // allow auto topic creation
+	}
+	if version >= 8 {
+		r.int8() // include cluster authorized operations
+		r.int8() // include topic authorized operations
+	}
+
+	leader := b
+	if b.leader != nil {
+		leader = b.leader
+	}
+	if version >= 3 {
+		w.int32(0) // throttle time
+	}
+	w.int32(2)
+	for id, broker := range []*standInBroker{b, leader} {
+		host, port, _ := net.SplitHostPort(broker.addr())
+		p, _ := strconv.Atoi(port)
+		w.int32(int32(id + 1))
+		w.string(host)
+		w.int32(int32(p))
+		w.nullString()
+	}
+	if version >= 2 {
+		w.string("stand-in")
+	}
+	w.int32(1) // controller ID
+	w.int32(int32(len(topics)))
+	for _, topic := range topics {
+		w.int16(0)
+		w.string(topic)
+		w.int8(0)
+		w.int32(1)
+		w.int16(0)
+		w.int32(0) // partition
+		w.int32(2) // leader
+		if version >= 7 {
+			w.int32(0) // leader epoch
+		}
+		w.int32(1)
+		w.int32(2)
+		w.int32(1)
+		w.int32(2)
+		if version >= 5 {
+			w.int32(0) // offline replicas
+		}
+		if version >= 8 {
+			w.int32(0) // topic authorized operations
+		}
+	}
+	if version >= 8 {
+		w.int32(0) // cluster authorized operations
+	}
+}
+
+func (b *standInBroker) produce(r *kafkaReader, version int16, w *kafkaWriter) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	r.string() // transactional ID
+	if acks := r.int16(); acks != kafkaAcksAll {
+		r.err = fmt.Errorf("unexpected acks %d", acks)
+	}
+	r.int32() // timeout
+	r.int32() // topics
+	topic := r.string()
+	r.int32() // partitions
+	partition := r.int32()
+	records, err := decodeRecordBatches(r.bytes())
+	if err != nil {
+		r.err = err
+		return
+	}
+
+	errorCode := b.failNext
+	b.failNext = 0
+	if b.leader != nil {
+		errorCode = 6 // not leader
+	}
+	if errorCode == 0 {
+		b.records = append(b.records, records...)
+	}
+
+	w.int32(1)
+	w.string(topic)
+	w.int32(1)
+	w.int32(partition)
+	w.int16(errorCode)
+	w.int64(int64(len(b.records) - len(records)))
+	w.int64(-1)
+	if version >= 5 {
+		w.int64(0) // log start offset
+	}
+	if version >= 8 {
+		w.int32(0) // record errors
+		w.nullString()
+	}
+	w.int32(0) // throttle time
+}
+
+func (r *kafkaReader) bytes() []byte {
+	n := r.int32()
+	if n < 0 {
+		return nil
+	}
+	return r.next(int(n))
+}
+
+func (r *kafkaReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.b)
+	if n <= 0 {
+		r.err = errors.New("malformed varint")
+		return 0
+	}
+	r.b = r.b[n:]
+	return v
+}
+
+func (r *kafkaReader) varbytes() []byte {
+	n := r.varint()
+	if n < 0 {
+		return nil
+	}
+	return r.next(int(n))
+}
+
+// decodeRecordBatches decodes the v2 record batches in b
+func decodeRecordBatches(b []byte) ([]kafkaRecord, error) {
+	records := []kafkaRecord{}
+	r := &kafkaReader{b: b}
+	for len(r.b) > 0 && r.err == nil {
+		r.int64() // base offset
+		batch := &kafkaReader{b: r.next(int(r.int32()))}
+		batch.int32() // partition leader epoch
+		if magic := batch.int8(); batch.err == nil && magic != 2 {
+			return nil, fmt.Errorf("unsupported record batch magic %d", magic)
+		}
+		crc := uint32(batch.int32())
+		if batch.err == nil && crc != crc32.Checksum(batch.b, castagnoli) {
+			return nil, errors.New("record batch CRC mismatch")
+		}
+		batch.int16() // attributes
+		batch.int32() // last offset delta
+		base := batch.int64()
+		batch.int64() // max timestamp
+		batch.int64() // producer ID
+		batch.int16() // producer epoch
+		batch.int32() // base sequence
+		count := batch.int32()
+		for i := int32(0); i < count && batch.err == nil; i++ {
+			record := &kafkaReader{b: batch.varbytes()}
+			record.int8() // attributes
+			decoded := kafkaRecord{Timestamp: base + record.varint()}
+			record.varint() // offset delta
+			decoded.Key = record.varbytes()
+			decoded.Value = record.varbytes()
+			headers := record.varint()
+			for j := int64(0); j < headers && record.err == nil; j++ {
+				decoded.Headers = append(decoded.Headers, [2][]byte{record.varbytes(), record.varbytes()})
+			}
+			if record.err != nil {
+				return nil, record.err
+			}
+			records = append(records, decoded)
+		}
+		if batch.err != nil {
+			return nil, batch.err
+		}
+	}
+	return records, r.err
+}
+
+func testEvents(ids ...string) api.EventList {
+	events := api.EventList{}
+	for i, id := range ids {
+		events = append(events, &api.Event{
+			Meta:      api.Meta{ID: id, CreatedAt: time.Date(2023, 3, 7, 15, 4, 5, 0, time.UTC).Add(time.Duration(i) * time.Second)},
+			Source:    "Dinosaurs",
+			SourceID:  "dinosaur-" + id,
+			EventType: api.CreateEventType,
+		})
+	}
+	return events
+}
+
+func TestRecordBatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	records := []kafkaRecord{
+		{Key: []byte("a"), Value: []byte("first"), Timestamp: 1000, Headers: [][2][]byte{{[]byte("h"), []byte("v")}}},
+		{Key: []byte("b"), Value: []byte("second"), Timestamp: 3000},
+	}
+	batch := encodeRecordBatch(records)
+	decoded, err := decodeRecordBatches(batch)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(decoded).To(HaveLen(2))
+	Expect(decoded[0]).To(Equal(records[0]))
+	Expect(string(decoded[1].Value)).To(Equal("second"))
+	Expect(decoded[1].Timestamp).To(Equal(int64(3000)))
+
+	batch[len(batch)-1] ^= 0xff
+	_, err = decodeRecordBatches(batch)
+	Expect(err).To(MatchError("record batch CRC mismatch"))
+}
+
+func TestKafkaPublisher(t *testing.T) {
+	RegisterTestingT(t)
+	broker := newStandInBroker(t, nil)
+	publisher := NewKafkaPublisher([]string{broker.addr()}, "events", "test", 5*time.Second)
+	defer publisher.Close()
+	Expect(publisher.Name()).To(Equal("kafka:events"))
+
+	Expect(publisher.Publish(context.Background(), testEvents("1", "2"))).To(Succeed())
+	Expect(publisher.Publish(context.Background(), testEvents("3"))).To(Succeed())
+
+	records := broker.produced()
+	Expect(records).To(HaveLen(3))
+	Expect(string(records[0].Key)).To(Equal("dinosaur-1"))
+	Expect(records[0].Timestamp).To(Equal(time.Date(2023, 3, 7, 15, 4, 5, 0, time.UTC).UnixMilli()))
+	Expect(records[0].Headers).To(Equal([][2][]byte{
+		{[]byte("event_type"), []byte("Create")},
+		{[]byte("source"), []byte("Dinosaurs")},
+	}))
+
+	var message Message
+	Expect(json.Unmarshal(records[1].Value, &message)).To(Succeed())
+	Expect(message.ID).To(Equal("2"))
+	Expect(message.SourceID).To(Equal("dinosaur-2"))
+	Expect(message.EventType).To(Equal(api.CreateEventType))
+
+	// the highest versions both speak are used
+	Expect(broker.requestedVersions()).To(Equal(kafkaVersions{
+		kafkaApiVersions.key: 0,
+		kafkaMetadata.key:    8,
+		kafkaProduce.key:     8,
+	}))
+}
+
+func TestKafkaPublisherVersions(t *testing.T) {
+	RegisterTestingT(t)
+
+	// a Kafka 1.0 broker
+	broker := newStandInBrokerOf(t, nil, map[int16][2]int16{
+		kafkaProduce.key:     {0, 5},
+		kafkaMetadata.key:    {0, 5},
+		kafkaApiVersions.key: {0, 1},
+	})
+	publisher := NewKafkaPublisher([]string{broker.addr()}, "events", "test", 5*time.Second)
+	defer publisher.Close()
+	Expect(publisher.Publish(context.Background(), testEvents("1"))).To(Succeed())
+	Expect(broker.produced()).To(HaveLen(1))
+	Expect(broker.requestedVersions()).To(Equal(kafkaVersions{
+		kafkaApiVersions.key: 0,
+		kafkaMetadata.key:    5,
+		kafkaProduce.key:     5,
+	}))
+
+	// a broker too old for the record batches of the publisher
+	old := newStandInBrokerOf(t, nil, map[int16][2]int16{
+		kafkaProduce.key:     {0, 2},
+		kafkaMetadata.key:    {0, 2},
+		kafkaApiVersions.key: {0, 0},
+	})
+	publisher = NewKafkaPublisher([]string{old.addr()}, "events", "test", 5*time.Second)
+	err := publisher.Publish(context.Background(), testEvents("1"))
+	Expect(err).To(MatchError(fmt.Sprintf("unable to agree on the protocol versions with kafka broker %s: "+
+		"the broker supports Produce v0 to v2, the publisher only v3 to v8", old.addr())))
+	Expect(old.requestedVersions()).To(Equal(kafkaVersions{kafkaApiVersions.key: 0}))
+}
+
+func TestKafkaPublisherLeader(t *testing.T) {
+	RegisterTestingT(t)
+	leader := newStandInBroker(t, nil)
+	bootstrap := newStandInBroker(t, leader)
+	publisher := NewKafkaPublisher([]string{"127.0.0.1:1", bootstrap.addr()}, "events", "test", 5*time.Second)
+	defer publisher.Close()
+
+	// the first broker is down, the second tells where the partition leader is
+	Expect(publisher.Publish(context.Background(), testEvents("1"))).To(Succeed())
+	Expect(leader.produced()).To(HaveLen(1))
+	Expect(bootstrap.produced()).To(BeEmpty())
+}
+
+func TestKafkaPublisherError(t *testing.T) {
+	RegisterTestingT(t)
+	broker := newStandInBroker(t, nil)
+	publisher := NewKafkaPublisher([]string{broker.addr()}, "events", "test", 5*time.Second)
+	defer publisher.Close()
+
+	broker.fail(7)
+	err := publisher.Publish(context.Background(), testEvents("1"))
+	var kafkaErr kafkaError
+	Expect(errors.As(err, &kafkaErr)).To(BeTrue())
+	Expect(kafkaErr).To(Equal(kafkaError(7)))
+	Expect(broker.produced()).To(BeEmpty())
+
+	// the publisher reconnects, and the batch can be published again
+	Expect(publisher.Publish(context.Background(), testEvents("1"))).To(Succeed())
+	Expect(broker.produced()).To(HaveLen(1))
+
+	unreachable := NewKafkaPublisher([]string{"127.0.0.1:1"}, "events", "test", time.Second)
+	err = unreachable.Publish(context.Background(), testEvents("1"))
+	Expect(err).To(HaveOccurred())
+	Expect(err.Error()).To(ContainSubstring("unable to connect"))
+}
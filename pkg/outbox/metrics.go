@@ -0,0 +1,73 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "outbox"
+
+// Names of the labels added to metrics:
+const (
+	metricsPublisherLabel = "publisher"
+	metricsStatusLabel    = "status"
+)
+
+// Description of the published events metric:
+var publishedEventsMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "published_events",
+		Help:      "Number of events published, including those published again after a failure.",
+	},
+	[]string{metricsPublisherLabel},
+)
+
+// Description of the publish duration metric:
+var publishDurationMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "publish_duration",
+		Help:      "Duration in seconds of the publishing of a batch of events.",
+		Buckets: []float64{
+			0.01,
+			0.05,
+			0.1,
+			0.5,
+			1.0,
+			5.0,
+		},
+	},
+	[]string{metricsPublisherLabel, metricsStatusLabel},
+)
+
+// Description of the lag metric:
+var lagMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "lag",
+		Help:      "Age in seconds of the last event published when it was published.",
+	},
+	[]string{metricsPublisherLabel},
+)
+
+func init() {
+	prometheus.MustRegister(publishedEventsMetric)
+	prometheus.MustRegister(publishDurationMetric)
+	prometheus.MustRegister(lagMetric)
+}
+
+func updatePublishMetrics(publisher, status string, events int, duration, lag time.Duration) {
+	publishDurationMetric.With(prometheus.Labels{
+		metricsPublisherLabel: publisher,
+		metricsStatusLabel:    status,
+	}).Observe(duration.Seconds())
+	if status != "OK" {
+		return
+	}
+	labels := prometheus.Labels{metricsPublisherLabel: publisher}
+	publishedEventsMetric.With(labels).Add(float64(events))
+	lagMetric.With(labels).Set(lag.Seconds())
+}
@@ -0,0 +1,51 @@
+/*
+Package outbox relays the events of the events table, the service's transactional outbox, to message brokers.
+
+Services write an api.Event in the same transaction as the change it is about, so the events table has an
+event for every committed change and none for those rolled back. A Relay reads the events in the order they
+were created and hands them to a Publisher, then records how far it has got as the publisher's offset.
+The offset is saved only once the publisher has returned, so events are published at least once: after a
+failure or a restart, the events of the last batch may be published again. Consumers should deduplicate by
+the event ID.
+*/
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+)
+
+// Publisher publishes events to a message broker
+type Publisher interface {
+	// Name identifies the publisher, and with it its offset. Publishers configured differently, such as
+	// to another topic, should be named differently so they start from the first event.
+	Name() string
+	// Publish publishes events, in order. The events are published only once it has returned nil.
+	Publish(ctx context.Context, events api.EventList) error
+}
+
+// Message is what publishers publish for an event
+type Message struct {
+	ID        string        `json:"id"`
+	Source    string        `json:"source"`
+	SourceID  string        `json:"source_id"`
+	EventType api.EventType `json:"event_type"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+func NewMessage(event *api.Event) *Message {
+	return &Message{
+		ID:        event.ID,
+		Source:    event.Source,
+		SourceID:  event.SourceID,
+		EventType: event.EventType,
+		CreatedAt: event.CreatedAt.UTC(),
+	}
+}
+
+func (m *Message) Marshal() ([]byte, error) {
+	return json.Marshal(m)
+}
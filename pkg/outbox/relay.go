@@ -0,0 +1,92 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// Relay publishes the events to one publisher, tracking the publisher's offset
+type Relay struct {
+	publisher   Publisher
+	outbox      dao.OutboxDao
+	lockFactory db.LockFactory
+	clock       clock.Clock
+	config      *config.OutboxConfig
+}
+
+// NewRelay creates a relay to publisher. Relays of several replicas of the service take turns through an
+// advisory lock on the publisher's name, so each batch is published once unless a publish fails.
+func NewRelay(publisher Publisher, outbox dao.OutboxDao, lockFactory db.LockFactory, clock clock.Clock, config *config.OutboxConfig) *Relay {
+	return &Relay{
+		publisher:   publisher,
+		outbox:      outbox,
+		lockFactory: lockFactory,
+		clock:       clock,
+		config:      config,
+	}
+}
+
+// RelayOnce publishes the next batch of events and advances the publisher's offset past them, returning
+// how many were published. Events are published once they are SettleTime old: events are ordered by their
+// creation, and a transaction committing late could otherwise add events before the offset.
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	name := r.publisher.Name()
+
+	lockOwnerID, err := r.lockFactory.NewAdvisoryLock(ctx, name, db.Outbox)
+	if err != nil {
+		return 0, err
+	}
+	defer r.lockFactory.Unlock(ctx, lockOwnerID)
+
+	offset, err := r.outbox.Offset(ctx, name)
+	if err != nil {
+		return 0, err
+	}
+	now := r.clock.Now()
+	events, err := r.outbox.Events(ctx, offset, now.Add(-r.config.SettleTime), r.config.BatchSize)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	if err := r.publisher.Publish(ctx, events); err != nil {
+		updatePublishMetrics(name, "error", len(events), r.clock.Now().Sub(now), 0)
+		return 0, err
+	}
+	last := events[len(events)-1]
+	updatePublishMetrics(name, "OK", len(events), r.clock.Now().Sub(now), r.clock.Now().Sub(last.CreatedAt))
+
+	offset.EventID = last.ID
+	offset.EventCreatedAt = last.CreatedAt
+	offset.UpdatedAt = r.clock.Now()
+	if err := r.outbox.SaveOffset(ctx, offset); err != nil {
+		return 0, err
+	}
+	return len(events), nil
+}
+
+// Run relays the events until ctx is done, draining any backlog a batch at a time and then polling for
+// new events every PollInterval
+func (r *Relay) Run(ctx context.Context) {
+	log := logger.NewOCMLogger(ctx)
+
+	for ctx.Err() == nil {
+		published, err := r.RelayOnce(ctx)
+		if err != nil {
+			log.Error(fmt.Sprintf("Unable to publish events to %s: %s", r.publisher.Name(), err))
+		}
+		if err == nil && published == r.config.BatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(r.config.PollInterval):
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/db/db_session"
+	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
+)
+
+// failingPublisher fails while err is set
+type failingPublisher struct {
+	Publisher
+	err error
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, events api.EventList) error {
+	if p.err != nil {
+		return p.err
+	}
+	return p.Publisher.Publish(ctx, events)
+}
+
+// lockedBuffer is a bytes.Buffer that may be read while a relay writes to it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+type relayTest struct {
+	clock  *clock.Fake
+	events dao.EventDao
+	outbox dao.OutboxDao
+	config *config.OutboxConfig
+}
+
+func newRelayTest(t *testing.T) *relayTest {
+	dbFactory := db_session.NewMemoryFactory(config.NewDatabaseConfig())
+	t.Cleanup(func() { _ = dbFactory.Close() })
+	fake := clock.NewFake(time.Date(2023, 3, 7, 15, 4, 5, 0, time.UTC))
+	dbFactory.SetClock(fake)
+
+	var sessionFactory db.SessionFactory = dbFactory
+	config := config.NewOutboxConfig()
+	config.BatchSize = 2
+	return &relayTest{
+		clock:  fake,
+		events: dao.NewEventDao(&sessionFactory),
+		outbox: dao.NewOutboxDao(&sessionFactory),
+		config: config,
+	}
+}
+
+func (rt *relayTest) relay(publisher Publisher) *Relay {
+	return NewRelay(publisher, rt.outbox, dbmocks.NewMockAdvisoryLockFactory(), rt.clock, rt.config)
+}
+
+func (rt *relayTest) createEvents(t *testing.T, sourceIDs ...string) {
+	for _, sourceID := range sourceIDs {
+		_, err := rt.events.Create(context.Background(), &api.Event{Source: "Dinosaurs", SourceID: sourceID, EventType: api.CreateEventType})
+		if err != nil {
+			t.Fatalf("Unable to create event: %s", err)
+		}
+		rt.clock.Advance(time.Second)
+	}
+}
+
+// published returns the source IDs of the events written to out
+func published(out *bytes.Buffer) []string {
+	sourceIDs := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var message Message
+		Expect(json.Unmarshal([]byte(line), &message)).To(Succeed())
+		sourceIDs = append(sourceIDs, message.SourceID)
+	}
+	return sourceIDs
+}
+
+func TestRelayOnce(t *testing.T) {
+	RegisterTestingT(t)
+	rt := newRelayTest(t)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	relay := rt.relay(NewWriterPublisher("test", &out))
+	rt.createEvents(t, "a", "b", "c")
+
+	// the events are too recent to be published yet
+	Expect(relay.RelayOnce(ctx)).To(Equal(0))
+
+	rt.clock.Advance(rt.config.SettleTime)
+	Expect(relay.RelayOnce(ctx)).To(Equal(2))
+	Expect(relay.RelayOnce(ctx)).To(Equal(1))
+	Expect(relay.RelayOnce(ctx)).To(Equal(0))
+	Expect(published(&out)).To(Equal([]string{"a", "b", "c"}))
+
+	offset, err := rt.outbox.Offset(ctx, "test")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(offset.EventID).NotTo(BeEmpty())
+
+	// new events are published after the offset
+	rt.createEvents(t, "d")
+	rt.clock.Advance(rt.config.SettleTime)
+	Expect(relay.RelayOnce(ctx)).To(Equal(1))
+	Expect(published(&out)).To(Equal([]string{"a", "b", "c", "d"}))
+}
+
+func TestRelayOffsets(t *testing.T) {
+	RegisterTestingT(t)
+	rt := newRelayTest(t)
+	ctx := context.Background()
+
+	var first, second bytes.Buffer
+	failing := &failingPublisher{Publisher: NewWriterPublisher("second", &second), err: errors.New("broker down")}
+	firstRelay := rt.relay(NewWriterPublisher("first", &first))
+	secondRelay := rt.relay(failing)
+
+	rt.createEvents(t, "a", "b")
+	rt.clock.Advance(rt.config.SettleTime)
+
+	// each publisher has its offset, a failing one stays where it was
+	Expect(firstRelay.RelayOnce(ctx)).To(Equal(2))
+	n, err := secondRelay.RelayOnce(ctx)
+	Expect(err).To(MatchError("broker down"))
+	Expect(n).To(Equal(0))
+	offset, err := rt.outbox.Offset(ctx, "second")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(offset.EventID).To(BeEmpty())
+
+	failing.err = nil
+	Expect(secondRelay.RelayOnce(ctx)).To(Equal(2))
+	Expect(firstRelay.RelayOnce(ctx)).To(Equal(0))
+	Expect(second.String()).To(Equal(first.String()))
+}
+
+func TestRelayRun(t *testing.T) {
+	RegisterTestingT(t)
+	rt := newRelayTest(t)
+	rt.config.PollInterval = 10 * time.Millisecond
+
+	var out lockedBuffer
+	relay := rt.relay(NewWriterPublisher("test", &out))
+	rt.createEvents(t, "a", "b", "c", "d", "e")
+	rt.clock.Advance(rt.config.SettleTime)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+	Eventually(func() int { return strings.Count(out.String(), "\n") }).Should(Equal(5))
+	cancel()
+	Eventually(done).Should(BeClosed())
+}
@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/pb"
+	"github.com/openshift-online/rh-trex/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+	"github.com/openshift-online/rh-trex/pkg/util"
+)
+
+var _ pb.DinosaursServer = &dinosaurServer{}
+
+type dinosaurServer struct {
+	pb.UnimplementedDinosaursServer
+
+	dinosaur services.DinosaurService
+	generic  services.GenericService
+	watches  *Watches
+}
+
+func NewDinosaurServer(dinosaur services.DinosaurService, generic services.GenericService, watches *Watches) *dinosaurServer {
+	return &dinosaurServer{
+		dinosaur: dinosaur,
+		generic:  generic,
+		watches:  watches,
+	}
+}
+
+func (s *dinosaurServer) Get(ctx context.Context, req *pb.GetDinosaurRequest) (*pb.Dinosaur, error) {
+	dinosaur, err := s.dinosaur.Get(ctx, req.Id)
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+	return presentDinosaur(dinosaur), nil
+}
+
+func (s *dinosaurServer) List(ctx context.Context, req *pb.ListDinosaursRequest) (*pb.ListDinosaursResponse, error) {
+	listArgs, err := listArguments(req.Search, req.OrderBy, req.PageSize, req.PageToken)
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+	var dinosaurs = []api.Dinosaur{}
+	paging, err := s.generic.List(ctx, "username", listArgs, &dinosaurs)
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+
+	resp := &pb.ListDinosaursResponse{
+		NextPageToken: nextPageToken(listArgs, paging),
+		Total:         paging.Total,
+	}
+	for i := range dinosaurs {
+		resp.Dinosaurs = append(resp.Dinosaurs, presentDinosaur(&dinosaurs[i]))
+	}
+	return resp, nil
+}
+
+func (s *dinosaurServer) Create(ctx context.Context, req *pb.CreateDinosaurRequest) (*pb.Dinosaur, error) {
+	switch {
+	case req.Dinosaur == nil:
+		return nil, handleError(ctx, errors.Validation("dinosaur is required"))
+	case req.Dinosaur.Id != "":
+		return nil, handleError(ctx, errors.Validation("id must be empty"))
+	case req.Dinosaur.Species == "":
+		return nil, handleError(ctx, errors.Validation("species is required"))
+	}
+
+	dinosaur, err := s.dinosaur.Create(ctx, &api.Dinosaur{Species: req.Dinosaur.Species})
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+	return presentDinosaur(dinosaur), nil
+}
+
+func (s *dinosaurServer) Update(ctx context.Context, req *pb.UpdateDinosaurRequest) (*pb.Dinosaur, error) {
+	if req.Species != nil && *req.Species == "" {
+		return nil, handleError(ctx, errors.Validation("species cannot be empty"))
+	}
+
+	found, err := s.dinosaur.Get(ctx, req.Id)
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+	if req.Species != nil {
+		found.Species = *req.Species
+	}
+
+	dinosaur, err := s.dinosaur.Replace(ctx, found)
+	if err != nil {
+		return nil, handleError(ctx, err)
+	}
+	return presentDinosaur(dinosaur), nil
+}
+
+func (s *dinosaurServer) Delete(ctx context.Context, req *pb.DeleteDinosaurRequest) (*emptypb.Empty, error) {
+	if err := s.dinosaur.Delete(ctx, req.Id); err != nil {
+		return nil, handleError(ctx, err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *dinosaurServer) Watch(req *pb.WatchDinosaursRequest, stream pb.Dinosaurs_WatchServer) error {
+	ctx := stream.Context()
+	events, stop := s.watches.Watch("Dinosaurs")
+	defer stop()
+
+	for {
+		var event *api.Event
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return handleWatchEnd(ctx, s.watches)
+			}
+			event = e
+		}
+
+		resp := &pb.WatchDinosaursResponse{
+			Type:     eventTypes[event.EventType],
+			Dinosaur: &pb.Dinosaur{Id: event.SourceID},
+		}
+		if event.EventType != api.DeleteEventType {
+			dinosaur, err := s.dinosaur.Get(ctx, event.SourceID)
+			if err != nil && err.Is404() {
+				// deleted since, its delete event follows
+				continue
+			}
+			if err != nil {
+				return handleError(ctx, err)
+			}
+			resp.Dinosaur = presentDinosaur(dinosaur)
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func presentDinosaur(dinosaur *api.Dinosaur) *pb.Dinosaur {
+	reference := presenters.PresentReference(dinosaur.ID, dinosaur)
+	return &pb.Dinosaur{
+		Id:        util.NilToEmptyString(reference.Id),
+		Kind:      util.NilToEmptyString(reference.Kind),
+		Href:      util.NilToEmptyString(reference.Href),
+		CreatedAt: timestamppb.New(dinosaur.CreatedAt),
+		UpdatedAt: timestamppb.New(dinosaur.UpdatedAt),
+		Species:   dinosaur.Species,
+	}
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/pb"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+	"github.com/openshift-online/rh-trex/pkg/services/mocks"
+)
+
+// newDinosaursClient serves a dinosaurServer in memory, with the interceptors that need no database
+func newDinosaursClient(t *testing.T, dinosaur services.DinosaurService, generic services.GenericService, watches *Watches) pb.DinosaursClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(OperationIDInterceptor, MetricsInterceptor),
+		grpc.ChainStreamInterceptor(OperationIDStreamInterceptor, MetricsStreamInterceptor),
+	)
+	pb.RegisterDinosaursServer(server, NewDinosaurServer(dinosaur, generic, watches))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewDinosaursClient(conn)
+}
+
+func TestDinosaurServerGet(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	client := newDinosaursClient(t, dinosaurService, mocks.NewMockGenericService(ctrl), NewWatches(mocks.NewMockEventService(ctrl)))
+
+	dinosaur := &api.Dinosaur{Species: "Triceratops"}
+	dinosaur.ID = "123"
+	dinosaurService.EXPECT().Get(gomock.Any(), "123").Return(dinosaur, nil)
+
+	found, err := client.Get(context.Background(), &pb.GetDinosaurRequest{Id: "123"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(found.Id).To(Equal("123"))
+	Expect(found.Kind).To(Equal("Dinosaur"))
+	Expect(found.Species).To(Equal("Triceratops"))
+}
+
+func TestDinosaurServerGetNotFound(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	client := newDinosaursClient(t, dinosaurService, mocks.NewMockGenericService(ctrl), NewWatches(mocks.NewMockEventService(ctrl)))
+
+	dinosaurService.EXPECT().Get(gomock.Any(), "404").Return(nil, errors.NotFound("Dinosaur with id='404' not found"))
+
+	var header metadata.MD
+	_, err := client.Get(context.Background(), &pb.GetDinosaurRequest{Id: "404"}, grpc.Header(&header))
+	st := status.Convert(err)
+	Expect(st.Code()).To(Equal(codes.NotFound))
+	Expect(st.Message()).To(Equal("Dinosaur with id='404' not found"))
+
+	// the error carries the operation ID returned in the header
+	Expect(header.Get(OperationIDHeader)).To(HaveLen(1))
+	info := st.Details()[0].(*errdetails.ErrorInfo)
+	Expect(info.Reason).To(Equal(*errors.CodeStr(errors.ErrorNotFound)))
+	Expect(info.Metadata).To(HaveKeyWithValue("operation_id", header.Get(OperationIDHeader)[0]))
+}
+
+func TestDinosaurServerCreate(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	client := newDinosaursClient(t, dinosaurService, mocks.NewMockGenericService(ctrl), NewWatches(mocks.NewMockEventService(ctrl)))
+
+	_, err := client.Create(context.Background(), &pb.CreateDinosaurRequest{Dinosaur: &pb.Dinosaur{}})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	_, err = client.Create(context.Background(), &pb.CreateDinosaurRequest{Dinosaur: &pb.Dinosaur{Id: "123", Species: "Triceratops"}})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	dinosaurService.EXPECT().Create(gomock.Any(), &api.Dinosaur{Species: "Triceratops"}).
+		DoAndReturn(func(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
+			dinosaur.ID = "123"
+			return dinosaur, nil
+		})
+	created, err := client.Create(context.Background(), &pb.CreateDinosaurRequest{Dinosaur: &pb.Dinosaur{Species: "Triceratops"}})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(created.Id).To(Equal("123"))
+}
+
+func TestDinosaurServerList(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	genericService := mocks.NewMockGenericService(ctrl)
+	client := newDinosaursClient(t, mocks.NewMockDinosaurService(ctrl), genericService, NewWatches(mocks.NewMockEventService(ctrl)))
+
+	var listed []*services.ListArguments
+	genericService.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(ctx context.Context, username string, args *services.ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+			listed = append(listed, args)
+			dinosaurs := resourceList.(*[]api.Dinosaur)
+			*dinosaurs = append(*dinosaurs, api.Dinosaur{Meta: api.Meta{ID: "1"}}, api.Dinosaur{Meta: api.Meta{ID: "2"}})
+			return &api.PagingMeta{Page: args.Page, Size: 2, Total: 4}, nil
+		})
+
+	req := &pb.ListDinosaursRequest{Search: "species = 'Triceratops'", OrderBy: []string{"created_at desc"}, PageSize: 2}
+	first, err := client.List(context.Background(), req)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(first.Dinosaurs).To(HaveLen(2))
+	Expect(first.Total).To(BeEquivalentTo(4))
+	Expect(first.NextPageToken).NotTo(BeEmpty())
+
+	req.PageToken = first.NextPageToken
+	last, err := client.List(context.Background(), req)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(last.NextPageToken).To(BeEmpty())
+
+	Expect(listed[0].Page).To(Equal(1))
+	Expect(listed[1].Page).To(Equal(2))
+	Expect(listed[1].Search).To(Equal("species = 'Triceratops'"))
+	Expect(listed[1].OrderBy).To(Equal([]string{"created_at desc"}))
+	Expect(listed[1].Size).To(BeEquivalentTo(2))
+
+	// the token pages through the list it came from only
+	req.Search = "species = 'Stegosaurus'"
+	_, err = client.List(context.Background(), req)
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
+
+func TestDinosaurServerWatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinosaurService := mocks.NewMockDinosaurService(ctrl)
+	eventService := mocks.NewMockEventService(ctrl)
+	watches := NewWatches(eventService)
+	client := newDinosaursClient(t, dinosaurService, mocks.NewMockGenericService(ctrl), watches)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := client.Watch(ctx, &pb.WatchDinosaursRequest{})
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(func() int { return watchers(watches, "Dinosaurs") }).Should(Equal(1))
+
+	dinosaur := &api.Dinosaur{Species: "Triceratops"}
+	dinosaur.ID = "123"
+	dinosaurService.EXPECT().Get(gomock.Any(), "123").Return(dinosaur, nil)
+	eventService.EXPECT().Get(gomock.Any(), "1").Return(&api.Event{Source: "Dinosaurs", SourceID: "123", EventType: api.CreateEventType}, nil)
+	eventService.EXPECT().Get(gomock.Any(), "2").Return(&api.Event{Source: "Eggs", SourceID: "456", EventType: api.CreateEventType}, nil)
+	eventService.EXPECT().Get(gomock.Any(), "3").Return(&api.Event{Source: "Dinosaurs", SourceID: "123", EventType: api.DeleteEventType}, nil)
+	watches.Handle("1")
+	watches.Handle("2")
+	watches.Handle("3")
+
+	created, err := stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(created.Type).To(Equal(pb.EventType_EVENT_TYPE_CREATE))
+	Expect(created.Dinosaur.Species).To(Equal("Triceratops"))
+
+	// events of other kinds aren't watched, deletes have the id of what was deleted only
+	deleted, err := stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(deleted.Type).To(Equal(pb.EventType_EVENT_TYPE_DELETE))
+	Expect(deleted.Dinosaur.Id).To(Equal("123"))
+	Expect(deleted.Dinosaur.Species).To(BeEmpty())
+
+	watches.Close()
+	_, err = stream.Recv()
+	Expect(status.Code(err)).To(Equal(codes.Unavailable))
+}
+
+func watchers(w *Watches, source string) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.watchers[source])
+}
@@ -0,0 +1,213 @@
+// Package rpc serves the kinds of the REST API over gRPC, through the same services. Its interceptors
+// do for gRPC calls what the REST API's middleware does for requests: operation IDs, JWT
+// authentication, authorization, database transactions and metrics.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/db/db_context"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// OperationIDHeader is the header the operation ID of a call is returned in, and that of its caller read from
+var OperationIDHeader = strings.ToLower(string(logger.OpIDHeader))
+
+// OperationIDInterceptor gives every call an operation ID, as logger.OperationIDMiddleware does requests
+func OperationIDInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withOperationID(ctx), req)
+}
+
+func OperationIDStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: withOperationID(ss.Context())})
+}
+
+func withOperationID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = logger.WithParentOpID(ctx, logger.ParentOperationID(firstValue(md, OperationIDHeader), firstValue(md, logger.TraceParentHeader)))
+	ctx = logger.WithOpID(ctx)
+	opID := logger.GetOperationID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(OperationIDHeader, opID))
+
+	// Add operation ID to sentry context
+	if hub := sentry.GetHubFromContext(ctx); hub != nil {
+		hub.ConfigureScope(func(scope *sentry.Scope) {
+			logger.SetSentryTags(ctx, scope)
+		})
+	}
+	return ctx
+}
+
+// firstValue returns the first value of key in md, or ""
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// TransactionInterceptor begins a database transaction for every unary call, as db.TransactionMiddleware
+// does for requests. Streams get none, a Watch would hold its transaction open for as long as it runs.
+func TransactionInterceptor(connection db.SessionFactory) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := db.NewContext(ctx, connection)
+		if err != nil {
+			log := logger.NewOCMLogger(ctx)
+			log.Extra("error", err.Error()).Error("Could not create transaction")
+			// use default error to avoid exposing internals to users
+			return nil, errors.GeneralError("").AsGRPCStatus(logger.GetOperationID(ctx)).Err()
+		}
+
+		if hub := sentry.GetHubFromContext(ctx); hub != nil {
+			hub.ConfigureScope(func(scope *sentry.Scope) {
+				if txid, ok := db_context.TxID(ctx); ok {
+					scope.SetTag("db_transaction_id", fmt.Sprintf("%d", txid))
+				}
+			})
+		}
+
+		// Returned from handlers and resolve transactions.
+		defer db.Resolve(ctx)
+
+		return handler(ctx, req)
+	}
+}
+
+// Authenticator authenticates calls with the HTTP middleware that authenticates REST requests, so both
+// APIs accept the same tokens. Each call is handed to the middleware as a POST of its full method name
+// with the call's authorization metadata as its Authorization header, and continues with the context
+// of the request the middleware passes on.
+type Authenticator struct {
+	handler http.Handler
+}
+
+// authenticatedKey holds where the last handler of the middleware stores the context it was passed
+type authenticatedKey struct{}
+
+// NewAuthenticator creates an Authenticator of middleware, outermost first. For example the ocm-sdk
+// authentication handler, which verifies the token, then auth.AuthMiddleware.AuthenticateAccountJWT.
+func NewAuthenticator(middleware ...func(next http.Handler) http.Handler) *Authenticator {
+	var handler http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if authenticated, ok := r.Context().Value(authenticatedKey{}).(*context.Context); ok {
+			*authenticated = r.Context()
+		}
+	})
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return &Authenticator{handler: handler}
+}
+
+// NewAuthorizer creates an Authenticator that authorizes calls with the middleware that authorizes REST
+// requests, so both APIs allow the same callers. It goes after the authentication, whose username the
+// middleware reads from the context of the call.
+func NewAuthorizer(authz auth.AuthorizationMiddleware) *Authenticator {
+	return NewAuthenticator(authz.AuthorizeApi)
+}
+
+func (a *Authenticator) Interceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Authenticator) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	var authenticated context.Context
+	r, err := http.NewRequestWithContext(context.WithValue(ctx, authenticatedKey{}, &authenticated), http.MethodPost, fullMethod, nil)
+	if err != nil {
+		return nil, handleError(ctx, errors.GeneralError("Unable to authenticate %s: %s", fullMethod, err))
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, authorization := range md.Get("authorization") {
+		r.Header.Add("Authorization", authorization)
+	}
+
+	w := &responseRecorder{header: http.Header{}}
+	a.handler.ServeHTTP(w, r)
+	if authenticated != nil {
+		return authenticated, nil
+	}
+
+	// the middleware responded with an error instead of passing the request on
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal(w.body, &body); err != nil || body.Reason == "" {
+		body.Reason = http.StatusText(w.code)
+	}
+	switch w.code {
+	case http.StatusUnauthorized:
+		return nil, handleError(ctx, errors.Unauthenticated(body.Reason))
+	case http.StatusForbidden:
+		return nil, handleError(ctx, errors.Forbidden(body.Reason))
+	default:
+		return nil, handleError(ctx, errors.GeneralError(body.Reason))
+	}
+}
+
+// responseRecorder is the response writer given to the authentication middleware, it keeps the error
+// responses the middleware writes
+type responseRecorder struct {
+	header http.Header
+	code   int
+	body   []byte
+}
+
+func (w *responseRecorder) Header() http.Header {
+	return w.header
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	w.body = append(w.body, b...)
+	return len(b), nil
+}
+
+func (w *responseRecorder) WriteHeader(code int) {
+	w.code = code
+}
+
+// serverStream is a stream whose handler runs with the context given by an interceptor
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// handleError logs err as handlers.handleError does, and returns it as the status of the call
+func handleError(ctx context.Context, err *errors.ServiceError) error {
+	log := logger.NewOCMLogger(ctx)
+	// If this is a 400 error, its the user's issue, log as info rather than error
+	if err.HttpCode >= 400 && err.HttpCode <= 499 {
+		log.Infof(err.Error())
+	} else {
+		log.Error(err.Error())
+	}
+	return err.AsGRPCStatus(logger.GetOperationID(ctx)).Err()
+}
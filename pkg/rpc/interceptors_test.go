@@ -0,0 +1,140 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex/pkg/auth"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// requireBearer stands in for the authentication handler: it rejects requests without a bearer token
+// as the ocm-sdk one does, and passes the others on with the token as the username
+func requireBearer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("Authorization")
+		if len(token) <= len("Bearer ") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"kind":   "Error",
+				"reason": "Request doesn't contain the 'Authorization' header",
+			})
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(auth.SetUsernameContext(r.Context(), token[len("Bearer "):])))
+	})
+}
+
+func TestAuthenticator(t *testing.T) {
+	RegisterTestingT(t)
+
+	var requested []string
+	authenticator := NewAuthenticator(requireBearer, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.Method+" "+r.URL.Path)
+			next.ServeHTTP(w, r)
+		})
+	})
+	info := &grpc.UnaryServerInfo{FullMethod: "/ocm_example.v1.Dinosaurs/Get"}
+
+	var username string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		username = auth.GetUsernameFromContext(ctx)
+		return "ok", nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer alice"))
+	resp, err := authenticator.Interceptor(ctx, nil, info, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp).To(Equal("ok"))
+	Expect(username).To(Equal("alice"))
+	Expect(requested).To(Equal([]string{"POST /ocm_example.v1.Dinosaurs/Get"}))
+
+	// calls the middleware rejects don't reach the handler
+	username = ""
+	_, err = authenticator.Interceptor(context.Background(), nil, info, handler)
+	st := status.Convert(err)
+	Expect(st.Code()).To(Equal(codes.Unauthenticated))
+	Expect(st.Message()).To(Equal("Request doesn't contain the 'Authorization' header"))
+	Expect(username).To(BeEmpty())
+}
+
+func TestAuthenticatorForbidden(t *testing.T) {
+	RegisterTestingT(t)
+
+	authenticator := NewAuthenticator(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		})
+	})
+	_, err := authenticator.Interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/ocm_example.v1.Dinosaurs/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	st := status.Convert(err)
+	Expect(st.Code()).To(Equal(codes.PermissionDenied))
+	Expect(st.Message()).To(Equal("Forbidden"))
+}
+
+// allowAlice stands in for the authorization middleware, it only passes on alice's requests
+type allowAlice struct{}
+
+func (allowAlice) AuthorizeApi(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth.GetUsernameFromContext(r.Context()) != "alice" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestAuthorizer(t *testing.T) {
+	RegisterTestingT(t)
+
+	authorizer := NewAuthorizer(allowAlice{})
+	info := &grpc.StreamServerInfo{FullMethod: "/ocm_example.v1.Dinosaurs/Watch"}
+	var called bool
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		called = true
+		return nil
+	}
+
+	err := authorizer.StreamInterceptor(nil, &serverStream{ctx: auth.SetUsernameContext(context.Background(), "alice")}, info, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(called).To(BeTrue())
+
+	called = false
+	err = authorizer.StreamInterceptor(nil, &serverStream{ctx: auth.SetUsernameContext(context.Background(), "bob")}, info, handler)
+	Expect(status.Code(err)).To(Equal(codes.PermissionDenied))
+	Expect(called).To(BeFalse())
+}
+
+func TestOperationIDInterceptor(t *testing.T) {
+	RegisterTestingT(t)
+
+	var opID, parentOpID string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		opID, parentOpID = logger.GetOperationID(ctx), logger.GetParentOperationID(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/ocm_example.v1.Dinosaurs/Get"}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(OperationIDHeader, "caller-1"))
+	_, err := OperationIDInterceptor(ctx, nil, info, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(parentOpID).To(Equal("caller-1"))
+	Expect(opID).NotTo(BeEmpty())
+	Expect(opID).NotTo(Equal(parentOpID))
+
+	_, err = OperationIDInterceptor(context.Background(), nil, info, handler)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(parentOpID).To(BeEmpty())
+}
@@ -0,0 +1,77 @@
+package rpc
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"hash/fnv"
+	"strings"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+// defaultPageSize is the page size of List calls that don't set one, as the REST API's default size
+const defaultPageSize = 100
+
+// pageToken is what a page token encodes: the page it fetches, and a hash of the search and order of the
+// list it pages through, so it isn't used to page through another
+type pageToken struct {
+	Page  int    `json:"page"`
+	Query uint32 `json:"query"`
+}
+
+// listArguments turns the search, order and paging of a List call into the services' ListArguments
+func listArguments(search string, orderBy []string, pageSize int32, token string) (*services.ListArguments, *errors.ServiceError) {
+	args := &services.ListArguments{
+		Page:    1,
+		Size:    defaultPageSize,
+		Search:  strings.Trim(search, " "),
+		OrderBy: orderBy,
+	}
+
+	switch {
+	case pageSize < 0:
+		return nil, errors.Validation("page_size must not be negative")
+	case pageSize > services.MAX_LIST_SIZE:
+		args.Size = services.MAX_LIST_SIZE
+	case pageSize > 0:
+		args.Size = int64(pageSize)
+	}
+
+	if token != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(token)
+		var page pageToken
+		if err == nil {
+			err = json.Unmarshal(decoded, &page)
+		}
+		if err != nil || page.Page < 1 {
+			return nil, errors.Validation("page_token is invalid")
+		}
+		if page.Query != queryHash(args) {
+			return nil, errors.Validation("page_token is of a list with another search or order_by")
+		}
+		args.Page = page.Page
+	}
+
+	return args, nil
+}
+
+// nextPageToken is the token of the page after the one listed, empty if it was the last
+func nextPageToken(args *services.ListArguments, paging *api.PagingMeta) string {
+	if int64(paging.Page)*args.Size >= paging.Total {
+		return ""
+	}
+	token, _ := json.Marshal(pageToken{Page: paging.Page + 1, Query: queryHash(args)})
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+func queryHash(args *services.ListArguments) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(args.Search))
+	for _, orderBy := range args.OrderBy {
+		h.Write([]byte{0})
+		h.Write([]byte(orderBy))
+	}
+	return h.Sum32()
+}
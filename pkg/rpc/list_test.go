@@ -0,0 +1,51 @@
+package rpc
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+func TestListArguments(t *testing.T) {
+	RegisterTestingT(t)
+
+	args, err := listArguments(" species = 'Triceratops' ", nil, 0, "")
+	Expect(err).To(BeNil())
+	Expect(args.Page).To(Equal(1))
+	Expect(args.Size).To(BeEquivalentTo(defaultPageSize))
+	Expect(args.Search).To(Equal("species = 'Triceratops'"))
+
+	args, err = listArguments("", nil, services.MAX_LIST_SIZE+1, "")
+	Expect(err).To(BeNil())
+	Expect(args.Size).To(BeEquivalentTo(services.MAX_LIST_SIZE))
+
+	_, err = listArguments("", nil, -1, "")
+	Expect(err).NotTo(BeNil())
+	_, err = listArguments("", nil, 0, "not a token")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestNextPageToken(t *testing.T) {
+	RegisterTestingT(t)
+
+	args, err := listArguments("", []string{"species", "created_at desc"}, 10, "")
+	Expect(err).To(BeNil())
+	token := nextPageToken(args, &api.PagingMeta{Page: 1, Size: 10, Total: 25})
+	Expect(token).NotTo(BeEmpty())
+
+	args, err = listArguments("", []string{"species", "created_at desc"}, 10, token)
+	Expect(err).To(BeNil())
+	Expect(args.Page).To(Equal(2))
+	token = nextPageToken(args, &api.PagingMeta{Page: 2, Size: 10, Total: 25})
+	args, err = listArguments("", []string{"species", "created_at desc"}, 10, token)
+	Expect(err).To(BeNil())
+	Expect(args.Page).To(Equal(3))
+	Expect(nextPageToken(args, &api.PagingMeta{Page: 3, Size: 5, Total: 25})).To(BeEmpty())
+
+	// a token is only good for the search and order it was listed with
+	_, err = listArguments("", []string{"created_at desc", "species"}, 10, token)
+	Expect(err).NotTo(BeNil())
+}
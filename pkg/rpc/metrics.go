@@ -0,0 +1,92 @@
+package rpc
+
+// This file contains the interceptors that generate metrics about gRPC calls, alongside those of the
+// REST API's server.MetricsMiddleware:
+//
+//	api_inbound_grpc_request_count - Number of calls served.
+//	api_inbound_grpc_request_duration - Time to process calls, in seconds. Streams are measured until
+//	they end.
+//
+// Both have the following labels:
+//
+//	method - Full name of the method, for example /ocm_example.v1.Dinosaurs/Get.
+//	code - Status code of the call, for example OK or NotFound.
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Subsystem used to define the metrics:
+const metricsSubsystem = "api_inbound"
+
+// Names of the labels added to metrics:
+const (
+	metricsMethodLabel = "method"
+	metricsCodeLabel   = "code"
+)
+
+// Description of the calls count metric:
+var requestCountMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "grpc_request_count",
+		Help:      "Number of gRPC calls served.",
+	},
+	[]string{metricsMethodLabel, metricsCodeLabel},
+)
+
+// Description of the call duration metric:
+var requestDurationMetric = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "grpc_request_duration",
+		Help:      "gRPC call duration in seconds.",
+		Buckets: []float64{
+			0.1,
+			1.0,
+			10.0,
+			30.0,
+		},
+	},
+	[]string{metricsMethodLabel, metricsCodeLabel},
+)
+
+func init() {
+	prometheus.MustRegister(requestCountMetric)
+	prometheus.MustRegister(requestDurationMetric)
+}
+
+// MetricsInterceptor collects the metrics of the calls it intercepts
+func MetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	before := time.Now()
+	resp, err := handler(ctx, req)
+	updateMetrics(info.FullMethod, err, time.Since(before))
+	return resp, err
+}
+
+func MetricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	before := time.Now()
+	err := handler(srv, ss)
+	updateMetrics(info.FullMethod, err, time.Since(before))
+	return err
+}
+
+// ResetMetricCollectors resets all prometheus collectors
+func ResetMetricCollectors() {
+	requestCountMetric.Reset()
+	requestDurationMetric.Reset()
+}
+
+func updateMetrics(method string, err error, elapsed time.Duration) {
+	labels := prometheus.Labels{
+		metricsMethodLabel: method,
+		metricsCodeLabel:   status.Code(err).String(),
+	}
+	requestCountMetric.With(labels).Inc()
+	requestDurationMetric.With(labels).Observe(elapsed.Seconds())
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/api/pb"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+	"github.com/openshift-online/rh-trex/pkg/services"
+)
+
+// watchBuffer is the number of events a Watch may fall behind by before it is ended
+const watchBuffer = 100
+
+// Watches hands the events notified on the events channel to the Watch calls of their kind. One listener
+// on the channel serves every Watch, its callback is Handle.
+type Watches struct {
+	events services.EventService
+
+	mu       sync.Mutex
+	watchers map[string]map[*watcher]bool
+	closed   bool
+}
+
+// watcher is a Watch call, its events are closed when it falls behind or the server stops
+type watcher struct {
+	events chan *api.Event
+}
+
+func NewWatches(events services.EventService) *Watches {
+	return &Watches{
+		events:   events,
+		watchers: map[string]map[*watcher]bool{},
+	}
+}
+
+// Handle hands the event with the id notified to the watchers of its source. Watchers that fell behind
+// are dropped, their events closed.
+func (w *Watches) Handle(id string) {
+	ctx := context.Background()
+	event, err := w.events.Get(ctx, id)
+	if err != nil {
+		log := logger.NewOCMLogger(ctx)
+		log.Extra("event_id", id).Extra("error", err.Error()).Error("Unable to get the event to watch")
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for watcher := range w.watchers[event.Source] {
+		select {
+		case watcher.events <- event:
+		default:
+			close(watcher.events)
+			delete(w.watchers[event.Source], watcher)
+		}
+	}
+}
+
+// Watch returns the events of source from now on, until stop is called. The events are closed when the
+// watch fell behind or the Watches were closed.
+func (w *Watches) Watch(source string) (events <-chan *api.Event, stop func()) {
+	watch := &watcher{events: make(chan *api.Event, watchBuffer)}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		close(watch.events)
+		return watch.events, func() {}
+	}
+	if w.watchers[source] == nil {
+		w.watchers[source] = map[*watcher]bool{}
+	}
+	w.watchers[source][watch] = true
+
+	return watch.events, func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if w.watchers[source][watch] {
+			close(watch.events)
+			delete(w.watchers[source], watch)
+		}
+	}
+}
+
+// Close ends every watch, and those started after
+func (w *Watches) Close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	for source, watchers := range w.watchers {
+		for watcher := range watchers {
+			close(watcher.events)
+		}
+		delete(w.watchers, source)
+	}
+}
+
+// Closed tells whether Close was called, so a watch whose events were closed knows if it fell behind
+func (w *Watches) Closed() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.closed
+}
+
+// handleWatchEnd is the status a Watch ends with when its events are closed
+func handleWatchEnd(ctx context.Context, watches *Watches) error {
+	if watches.Closed() {
+		return status.Error(codes.Unavailable, "the server is shutting down")
+	}
+	log := logger.NewOCMLogger(ctx)
+	log.Infof("Ending a watch that fell behind by %d events", watchBuffer)
+	return status.Error(codes.ResourceExhausted, "the watch fell behind, list and watch again")
+}
+
+// eventTypes are the watch event types of the api event types
+var eventTypes = map[api.EventType]pb.EventType{
+	api.CreateEventType: pb.EventType_EVENT_TYPE_CREATE,
+	api.UpdateEventType: pb.EventType_EVENT_TYPE_UPDATE,
+	api.DeleteEventType: pb.EventType_EVENT_TYPE_DELETE,
+}
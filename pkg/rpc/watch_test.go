@@ -0,0 +1,58 @@
+package rpc
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/services/mocks"
+)
+
+func TestWatchesFallBehind(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	eventService := mocks.NewMockEventService(ctrl)
+	eventService.EXPECT().Get(gomock.Any(), "1").Return(&api.Event{Source: "Dinosaurs", SourceID: "123"}, nil).AnyTimes()
+	watches := NewWatches(eventService)
+
+	events, stop := watches.Watch("Dinosaurs")
+	defer stop()
+	for i := 0; i < watchBuffer+1; i++ {
+		watches.Handle("1")
+	}
+
+	// the events buffered are received before the watch ends
+	received := 0
+	for range events {
+		received++
+	}
+	Expect(received).To(Equal(watchBuffer))
+	Expect(watches.Closed()).To(BeFalse())
+	Expect(watchers(watches, "Dinosaurs")).To(Equal(0))
+}
+
+func TestWatchesStop(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	eventService := mocks.NewMockEventService(ctrl)
+	watches := NewWatches(eventService)
+
+	_, stop := watches.Watch("Dinosaurs")
+	Expect(watchers(watches, "Dinosaurs")).To(Equal(1))
+	stop()
+	stop()
+	Expect(watchers(watches, "Dinosaurs")).To(Equal(0))
+
+	// events that can't be found are dropped
+	eventService.EXPECT().Get(gomock.Any(), "404").Return(nil, errors.NotFound("Event with id='404' not found"))
+	watches.Handle("404")
+
+	watches.Close()
+	events, _ := watches.Watch("Dinosaurs")
+	Expect(events).To(BeClosed())
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// customKindName is the form of the names of custom kinds, which are in the paths of their resources
+var customKindName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+//go:generate mockgen -source=custom_kinds.go -destination=mocks/mock_custom_kinds.go -package=mocks
+type CustomKindService interface {
+	Get(ctx context.Context, name string) (*api.CustomKind, *errors.ServiceError)
+	Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, *errors.ServiceError)
+	Delete(ctx context.Context, name string) *errors.ServiceError
+	All(ctx context.Context) (api.CustomKindList, *errors.ServiceError)
+}
+
+func NewCustomKindService(lockFactory db.LockFactory, customKindDao dao.CustomKindDao, customResourceDao dao.CustomResourceDao) CustomKindService {
+	return &sqlCustomKindService{
+		lockFactory:       lockFactory,
+		customKindDao:     customKindDao,
+		customResourceDao: customResourceDao,
+	}
+}
+
+var _ CustomKindService = &sqlCustomKindService{}
+
+type sqlCustomKindService struct {
+	lockFactory       db.LockFactory
+	customKindDao     dao.CustomKindDao
+	customResourceDao dao.CustomResourceDao
+}
+
+func (s *sqlCustomKindService) Get(ctx context.Context, name string) (*api.CustomKind, *errors.ServiceError) {
+	kind, err := s.customKindDao.Get(ctx, name)
+	if err != nil {
+		return nil, handleGetError("Custom kind", "name", name, err)
+	}
+	return kind, nil
+}
+
+func (s *sqlCustomKindService) Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, *errors.ServiceError) {
+	if !customKindName.MatchString(kind.Name) {
+		return nil, errors.Validation("name must be lowercase letters, digits and underscores, starting with a letter")
+	}
+	if _, err := compileSchema(kind); err != nil {
+		return nil, errors.Validation("Invalid schema: %s", err)
+	}
+
+	kind, err := s.customKindDao.Create(ctx, kind)
+	if err != nil {
+		return nil, handleCreateError("Custom kind", err)
+	}
+	return kind, nil
+}
+
+// Delete deletes the kind named name, once its resources are deleted
+func (s *sqlCustomKindService) Delete(ctx context.Context, name string) *errors.ServiceError {
+	// the resources are created holding the lock of their kind, so none is created between the count and the
+	// deletion
+	lockOwnerID, lErr := s.lockFactory.NewAdvisoryLock(ctx, name, db.CustomKinds)
+	if lErr != nil {
+		return errors.DatabaseAdvisoryLock(lErr)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	kind, err := s.customKindDao.Get(ctx, name)
+	if err != nil {
+		return handleGetError("Custom kind", "name", name, err)
+	}
+	count, err := s.customResourceDao.CountByKind(ctx, name)
+	if err != nil {
+		return handleDeleteError("Custom kind", err)
+	}
+	if count > 0 {
+		return errors.Conflict("Custom kind '%s' still has %d resources", name, count)
+	}
+
+	if err := s.customKindDao.Delete(ctx, kind.ID); err != nil {
+		return handleDeleteError("Custom kind", err)
+	}
+	return nil
+}
+
+func (s *sqlCustomKindService) All(ctx context.Context) (api.CustomKindList, *errors.ServiceError) {
+	kinds, err := s.customKindDao.All(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get all custom kinds: %s", err)
+	}
+	return kinds, nil
+}
+
+// compileSchema compiles the JSON Schema of kind. Schemas can only refer to themselves, they aren't
+// allowed to load anything, from the file system or the network.
+func compileSchema(kind *api.CustomKind) (*jsonschema.Schema, error) {
+	if len(kind.Schema) == 0 {
+		return nil, fmt.Errorf("schema is required")
+	}
+	url := fmt.Sprintf("urn:custom-kinds:%s", kind.Name)
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("%s can't be loaded, custom kinds' schemas can only refer to themselves", s)
+	}
+	if err := compiler.AddResource(url, bytes.NewReader(kind.Schema)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(url)
+}
+
+// validateProperties validates properties, a JSON object, against schema
+func validateProperties(schema *jsonschema.Schema, properties api.JSON) *errors.ServiceError {
+	decoder := json.NewDecoder(bytes.NewReader(properties))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return errors.Validation("Invalid properties: %s", err)
+	}
+	if _, ok := value.(map[string]interface{}); !ok {
+		return errors.Validation("properties must be an object")
+	}
+	if err := schema.Validate(value); err != nil {
+		if verr, ok := err.(*jsonschema.ValidationError); ok {
+			return errors.Validation("Invalid properties: %s", strings.Join(validationMessages(verr), "; "))
+		}
+		return errors.Validation("Invalid properties: %s", err)
+	}
+	return nil
+}
+
+// validationMessages are the messages of the innermost causes of err, where the properties went wrong
+func validationMessages(err *jsonschema.ValidationError) []string {
+	if len(err.Causes) == 0 {
+		location := err.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		return []string{fmt.Sprintf("%s: %s", location, err.Message)}
+	}
+	var messages []string
+	for _, cause := range err.Causes {
+		messages = append(messages, validationMessages(cause)...)
+	}
+	return messages
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+	"github.com/openshift-online/rh-trex/pkg/logger"
+)
+
+// CustomResourcesEventSource is the source of the events of the resources of every custom kind
+const CustomResourcesEventSource = "CustomResources"
+
+//go:generate mockgen -source=custom_resources.go -destination=mocks/mock_custom_resources.go -package=mocks
+type CustomResourceService interface {
+	Get(ctx context.Context, kind, id string) (*api.CustomResource, *errors.ServiceError)
+	Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError)
+	Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError)
+	Delete(ctx context.Context, kind, id string) *errors.ServiceError
+	List(ctx context.Context, kind string, args *ListArguments) (api.CustomResourceList, *api.PagingMeta, *errors.ServiceError)
+
+	// idempotent functions for the control plane, but can also be called synchronously by any actor
+	OnUpsert(ctx context.Context, id string) error
+	OnDelete(ctx context.Context, id string) error
+}
+
+func NewCustomResourceService(lockFactory db.LockFactory, sessionFactory *db.SessionFactory, customKindDao dao.CustomKindDao, customResourceDao dao.CustomResourceDao, generic GenericService, events EventService, admission *admission.Chain, hooks *Hooks) CustomResourceService {
+	return &sqlCustomResourceService{
+		lockFactory:       lockFactory,
+		customKindDao:     customKindDao,
+		customResourceDao: customResourceDao,
+		generic:           generic,
+		events:            events,
+		writer:            newKindWriter[api.CustomResource](sessionFactory, admission, hooks),
+	}
+}
+
+var _ CustomResourceService = &sqlCustomResourceService{}
+
+type sqlCustomResourceService struct {
+	lockFactory       db.LockFactory
+	customKindDao     dao.CustomKindDao
+	customResourceDao dao.CustomResourceDao
+	generic           GenericService
+	events            EventService
+	// writer runs the admission and lifecycle hooks around the writes
+	writer kindWriter[api.CustomResource]
+}
+
+func (s *sqlCustomResourceService) OnUpsert(ctx context.Context, id string) error {
+	logger := logger.NewOCMLogger(ctx)
+
+	resource, err := s.customResourceDao.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Do idempotent somethings with this %s: %s", resource.Kind, resource.ID)
+
+	return nil
+}
+
+func (s *sqlCustomResourceService) OnDelete(ctx context.Context, id string) error {
+	logger := logger.NewOCMLogger(ctx)
+	logger.Infof("This custom resource was deleted: %s", id)
+	return nil
+}
+
+// Get finds the resource of kind with id, the resources of the other kinds sharing its table aren't found
+func (s *sqlCustomResourceService) Get(ctx context.Context, kind, id string) (*api.CustomResource, *errors.ServiceError) {
+	resource, err := s.customResourceDao.Get(ctx, id)
+	if err != nil {
+		return nil, handleGetError(kind, "id", id, err)
+	}
+	if resource.Kind != kind {
+		return nil, errors.NotFound("%s with id='%s' not found", kind, id)
+	}
+	return resource, nil
+}
+
+func (s *sqlCustomResourceService) Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+	// the lock of the kind keeps it from being deleted until the resource is committed
+	lockOwnerID, lErr := s.lockFactory.NewAdvisoryLock(ctx, resource.Kind, db.CustomKinds)
+	if lErr != nil {
+		return nil, errors.DatabaseAdvisoryLock(lErr)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	// the hooks are called before the validation, which validates their changes too
+	return s.writer.create(ctx, resource.Kind, resource, func(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+		if err := s.validate(ctx, resource); err != nil {
+			return nil, err
+		}
+
+		created, err := s.customResourceDao.Create(ctx, resource)
+		if err != nil {
+			return nil, handleCreateError(resource.Kind, err)
+		}
+
+		_, eErr := s.events.Create(ctx, &api.Event{
+			Source:    CustomResourcesEventSource,
+			SourceID:  created.ID,
+			EventType: api.CreateEventType,
+		})
+		if eErr != nil {
+			return nil, eErr
+		}
+		return created, nil
+	})
+}
+
+// Replace replaces the properties of the resource
+func (s *sqlCustomResourceService) Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+	// the properties are read, modified and written, the advisory lock keeps concurrent replaces from
+	// overwriting each other
+	lockOwnerID, lErr := s.lockFactory.NewAdvisoryLock(ctx, resource.ID, db.CustomResources)
+	if lErr != nil {
+		return nil, errors.DatabaseAdvisoryLock(lErr)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	found, svcErr := s.Get(ctx, resource.Kind, resource.ID)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	old := *found
+	found.Properties = resource.Properties
+	return s.writer.update(ctx, found.Kind, found, func() (*api.CustomResource, *errors.ServiceError) {
+		return &old, nil
+	}, func(ctx context.Context, found *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+		if err := s.validate(ctx, found); err != nil {
+			return nil, err
+		}
+
+		updated, err := s.customResourceDao.Replace(ctx, found)
+		if err != nil {
+			return nil, handleUpdateError(resource.Kind, err)
+		}
+
+		_, eErr := s.events.Create(ctx, &api.Event{
+			Source:    CustomResourcesEventSource,
+			SourceID:  updated.ID,
+			EventType: api.UpdateEventType,
+		})
+		if eErr != nil {
+			return nil, eErr
+		}
+		return updated, nil
+	})
+}
+
+func (s *sqlCustomResourceService) Delete(ctx context.Context, kind, id string) *errors.ServiceError {
+	// the resource is read whatever the hooks, to tell whether it is of kind
+	found, svcErr := s.Get(ctx, kind, id)
+	if svcErr != nil {
+		return svcErr
+	}
+	return s.writer.delete(ctx, kind, func() (*api.CustomResource, *errors.ServiceError) {
+		return found, nil
+	}, func(ctx context.Context) *errors.ServiceError {
+		if err := s.customResourceDao.Delete(ctx, id); err != nil {
+			return handleDeleteError(kind, err)
+		}
+
+		_, err := s.events.Create(ctx, &api.Event{
+			Source:    CustomResourcesEventSource,
+			SourceID:  id,
+			EventType: api.DeleteEventType,
+		})
+		if err != nil {
+			return handleDeleteError(kind, err)
+		}
+		return nil
+	})
+}
+
+// List lists the resources of kind matching the search of args, which can search their properties with
+// properties.<name>
+func (s *sqlCustomResourceService) List(ctx context.Context, kind string, args *ListArguments) (api.CustomResourceList, *api.PagingMeta, *errors.ServiceError) {
+	if _, err := s.customKindDao.Get(ctx, kind); err != nil {
+		return nil, nil, handleGetError("Custom kind", "name", kind, err)
+	}
+
+	// the search only reaches the resources of kind
+	scoped := *args
+	scoped.Scope = map[string]interface{}{"kind": kind}
+
+	var resources []api.CustomResource
+	paging, err := s.generic.List(ctx, "username", &scoped, &resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	list := make(api.CustomResourceList, len(resources))
+	for i := range resources {
+		list[i] = &resources[i]
+	}
+	return list, paging, nil
+}
+
+// validate validates the properties of resource against the schema of its kind
+func (s *sqlCustomResourceService) validate(ctx context.Context, resource *api.CustomResource) *errors.ServiceError {
+	kind, err := s.customKindDao.Get(ctx, resource.Kind)
+	if err != nil {
+		return handleGetError("Custom kind", "name", resource.Kind, err)
+	}
+	schema, err := compileSchema(kind)
+	if err != nil {
+		return errors.GeneralError("Unable to compile the schema of custom kind '%s': %s", kind.Name, err)
+	}
+	return validateProperties(schema, resource.Properties)
+}
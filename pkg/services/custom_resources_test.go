@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+var featureFlag = &api.CustomKind{
+	Meta: api.Meta{ID: "1"},
+	Name: "feature_flag",
+	Schema: api.JSON(`{
+		"type": "object",
+		"properties": {
+			"enabled": {"type": "boolean"},
+			"rollout": {"type": "integer", "minimum": 0, "maximum": 100}
+		},
+		"required": ["enabled"]
+	}`),
+}
+
+// genericServiceFake lists its resources, whatever the arguments, recording the arguments
+type genericServiceFake struct {
+	resources []api.CustomResource
+	lists     []ListArguments
+}
+
+func (g *genericServiceFake) List(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+	g.lists = append(g.lists, *args)
+	*resourceList.(*[]api.CustomResource) = append([]api.CustomResource{}, g.resources...)
+	return &api.PagingMeta{Page: args.Page, Size: int64(len(g.resources)), Total: int64(len(g.resources))}, nil
+}
+
+func TestCustomKindCreate(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	kindService := NewCustomKindService(dbmocks.NewMockAdvisoryLockFactory(), kindDao, mocks.NewMockCustomResourceDao(ctrl))
+
+	invalid := map[string]*api.CustomKind{
+		"name must be lowercase letters, digits and underscores, starting with a letter": {Name: "FeatureFlag", Schema: featureFlag.Schema},
+		"Invalid schema: schema is required":                                             {Name: "feature_flag"},
+	}
+	for reason, kind := range invalid {
+		_, err := kindService.Create(context.Background(), kind)
+		gm.Expect(err).NotTo(gm.BeNil())
+		gm.Expect(err.Code).To(gm.Equal(errors.ErrorValidation))
+		gm.Expect(err.Reason).To(gm.Equal(reason))
+	}
+
+	// schemas must be valid, and can't load anything
+	for _, schema := range []string{`{"type": "boolean`, `{"type": "nope"}`, `{"$ref": "file:///etc/passwd"}`} {
+		_, err := kindService.Create(context.Background(), &api.CustomKind{Name: "feature_flag", Schema: api.JSON(schema)})
+		gm.Expect(err).NotTo(gm.BeNil())
+		gm.Expect(err.Code).To(gm.Equal(errors.ErrorValidation))
+		gm.Expect(err.Reason).To(gm.HavePrefix("Invalid schema: "))
+	}
+
+	kindDao.EXPECT().Create(gomock.Any(), featureFlag).Return(featureFlag, nil)
+	created, err := kindService.Create(context.Background(), featureFlag)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(created).To(gm.Equal(featureFlag))
+}
+
+func TestCustomKindDeleteWithResources(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	resourceDao := mocks.NewMockCustomResourceDao(ctrl)
+	lockFactory := dbmocks.NewMockLockFactory(ctrl)
+	kindService := NewCustomKindService(lockFactory, kindDao, resourceDao)
+
+	lockFactory.EXPECT().NewAdvisoryLock(gomock.Any(), "feature_flag", db.CustomKinds).Return("owner", nil).Times(2)
+	lockFactory.EXPECT().Unlock(gomock.Any(), "owner").Times(2)
+	kindDao.EXPECT().Get(gomock.Any(), "feature_flag").Return(featureFlag, nil).Times(2)
+	resourceDao.EXPECT().CountByKind(gomock.Any(), "feature_flag").Return(int64(2), nil)
+	err := kindService.Delete(context.Background(), "feature_flag")
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.HttpCode).To(gm.Equal(http.StatusConflict))
+
+	resourceDao.EXPECT().CountByKind(gomock.Any(), "feature_flag").Return(int64(0), nil)
+	kindDao.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+	gm.Expect(kindService.Delete(context.Background(), "feature_flag")).To(gm.BeNil())
+}
+
+func TestCustomResourceLocks(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	resourceDao := mocks.NewMockCustomResourceDao(ctrl)
+	eventDao := mocks.NewMockEventDao(ctrl)
+	lockFactory := dbmocks.NewMockLockFactory(ctrl)
+	resourceService := NewCustomResourceService(lockFactory, nil, kindDao, resourceDao, &genericServiceFake{}, NewEventService(eventDao), admission.NewChain(), NewHooks())
+	kindDao.EXPECT().Get(gomock.Any(), "feature_flag").Return(featureFlag, nil).AnyTimes()
+	eventDao.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&api.Event{}, nil).AnyTimes()
+
+	// a resource is created holding the lock of its kind, which the kind's deletion takes too
+	resource := &api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag", Properties: api.JSON(`{"enabled": true}`)}
+	gomock.InOrder(
+		lockFactory.EXPECT().NewAdvisoryLock(gomock.Any(), "feature_flag", db.CustomKinds).Return("kind", nil),
+		resourceDao.EXPECT().Create(gomock.Any(), resource).Return(resource, nil),
+		lockFactory.EXPECT().Unlock(gomock.Any(), "kind"),
+	)
+	_, err := resourceService.Create(context.Background(), resource)
+	gm.Expect(err).To(gm.BeNil())
+
+	// the properties are read and replaced holding the lock of the resource
+	gomock.InOrder(
+		lockFactory.EXPECT().NewAdvisoryLock(gomock.Any(), "a", db.CustomResources).Return("resource", nil),
+		resourceDao.EXPECT().Get(gomock.Any(), "a").Return(resource, nil),
+		resourceDao.EXPECT().Replace(gomock.Any(), gomock.Any()).Return(resource, nil),
+		lockFactory.EXPECT().Unlock(gomock.Any(), "resource"),
+	)
+	_, err = resourceService.Replace(context.Background(), &api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag", Properties: api.JSON(`{"enabled": false}`)})
+	gm.Expect(err).To(gm.BeNil())
+
+	lockFactory.EXPECT().NewAdvisoryLock(gomock.Any(), "a", db.CustomResources).Return("", fmt.Errorf("no connection"))
+	_, err = resourceService.Replace(context.Background(), &api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag"})
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Code).To(gm.Equal(errors.ErrorDatabaseAdvisoryLock))
+}
+
+func TestCustomResourceCreate(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	resourceDao := mocks.NewMockCustomResourceDao(ctrl)
+	eventDao := mocks.NewMockEventDao(ctrl)
+	resourceService := NewCustomResourceService(dbmocks.NewMockAdvisoryLockFactory(), nil, kindDao, resourceDao, &genericServiceFake{}, NewEventService(eventDao), admission.NewChain(), NewHooks())
+
+	kindDao.EXPECT().Get(gomock.Any(), "feature_flag").Return(featureFlag, nil).AnyTimes()
+	kindDao.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+
+	invalid := map[string]*api.CustomResource{
+		"Invalid properties: /enabled: expected boolean, but got string":                               {Kind: "feature_flag", Properties: api.JSON(`{"enabled": "yes"}`)},
+		"Invalid properties: /: missing properties: 'enabled'; /rollout: must be <= 100 but found 101": {Kind: "feature_flag", Properties: api.JSON(`{"rollout": 101}`)},
+		"properties must be an object": {Kind: "feature_flag", Properties: api.JSON(`[true]`)},
+	}
+	for reason, resource := range invalid {
+		_, err := resourceService.Create(context.Background(), resource)
+		gm.Expect(err).NotTo(gm.BeNil())
+		gm.Expect(err.Code).To(gm.Equal(errors.ErrorValidation))
+		gm.Expect(err.Reason).To(gm.Equal(reason))
+	}
+
+	_, err := resourceService.Create(context.Background(), &api.CustomResource{Kind: "nope", Properties: api.JSON(`{}`)})
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Is404()).To(gm.BeTrue())
+
+	resource := &api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag", Properties: api.JSON(`{"enabled": true, "rollout": 50}`)}
+	resourceDao.EXPECT().Create(gomock.Any(), resource).Return(resource, nil)
+	eventDao.EXPECT().Create(gomock.Any(), &api.Event{
+		Source:    CustomResourcesEventSource,
+		SourceID:  "a",
+		EventType: api.CreateEventType,
+	}).Return(&api.Event{}, nil)
+	created, err := resourceService.Create(context.Background(), resource)
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(created).To(gm.Equal(resource))
+}
+
+func TestCustomResourceGetOfOtherKind(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	resourceDao := mocks.NewMockCustomResourceDao(ctrl)
+	resourceService := NewCustomResourceService(dbmocks.NewMockAdvisoryLockFactory(), nil, mocks.NewMockCustomKindDao(ctrl), resourceDao, &genericServiceFake{}, NewEventService(mocks.NewMockEventDao(ctrl)), admission.NewChain(), NewHooks())
+
+	resourceDao.EXPECT().Get(gomock.Any(), "a").Return(&api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag"}, nil).Times(2)
+	found, err := resourceService.Get(context.Background(), "feature_flag", "a")
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(found.ID).To(gm.Equal("a"))
+
+	_, err = resourceService.Get(context.Background(), "quota", "a")
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Reason).To(gm.Equal("quota with id='a' not found"))
+}
+
+func TestCustomResourceListScopedToKind(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	generic := &genericServiceFake{resources: []api.CustomResource{{Meta: api.Meta{ID: "a"}, Kind: "feature_flag"}}}
+	resourceService := NewCustomResourceService(dbmocks.NewMockAdvisoryLockFactory(), nil, kindDao, mocks.NewMockCustomResourceDao(ctrl), generic, NewEventService(mocks.NewMockEventDao(ctrl)), admission.NewChain(), NewHooks())
+
+	kindDao.EXPECT().Get(gomock.Any(), "feature_flag").Return(featureFlag, nil).AnyTimes()
+
+	for _, search := range []string{"", "properties.enabled = 'true'"} {
+		args := &ListArguments{Page: 1, Size: 100, Search: search}
+		resources, paging, err := resourceService.List(context.Background(), "feature_flag", args)
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(resources).To(gm.HaveLen(1))
+		gm.Expect(paging.Total).To(gm.Equal(int64(1)))
+		// the arguments given aren't changed
+		gm.Expect(args.Scope).To(gm.BeNil())
+	}
+	// the search is listed as is, within the resources of the kind
+	gm.Expect(generic.lists).To(gm.HaveLen(2))
+	for i, search := range []string{"", "properties.enabled = 'true'"} {
+		gm.Expect(generic.lists[i].Search).To(gm.Equal(search))
+		gm.Expect(generic.lists[i].Scope).To(gm.Equal(map[string]interface{}{"kind": "feature_flag"}))
+	}
+}
+
+func TestCustomResourceAdmission(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	kindDao := mocks.NewMockCustomKindDao(ctrl)
+	resourceDao := mocks.NewMockCustomResourceDao(ctrl)
+	chain := admission.NewChain(admission.Registration{
+		Name:  "rollout",
+		Kinds: []string{"feature_flag"},
+		Hook: admission.HookFunc(func(ctx context.Context, request *admission.Request) (*admission.Response, error) {
+			if request.Operation == admission.Delete {
+				return admission.Deny("flags are forever"), nil
+			}
+			return &admission.Response{Allowed: true, Patch: []byte(`[{"op": "add", "path": "/properties/rollout", "value": 101}]`)}, nil
+		}),
+	})
+	resourceService := NewCustomResourceService(dbmocks.NewMockAdvisoryLockFactory(), nil, kindDao, resourceDao, &genericServiceFake{}, NewEventService(mocks.NewMockEventDao(ctrl)), chain, NewHooks())
+	kindDao.EXPECT().Get(gomock.Any(), "feature_flag").Return(featureFlag, nil).AnyTimes()
+
+	// the hook's changes are validated too, and the DAO is never reached
+	_, err := resourceService.Create(context.Background(), &api.CustomResource{Kind: "feature_flag", Properties: api.JSON(`{"enabled": true}`)})
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Reason).To(gm.Equal("Invalid properties: /rollout: must be <= 100 but found 101"))
+
+	resourceDao.EXPECT().Get(gomock.Any(), "a").Return(&api.CustomResource{Meta: api.Meta{ID: "a"}, Kind: "feature_flag"}, nil)
+	err = resourceService.Delete(context.Background(), "feature_flag", "a")
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Code).To(gm.Equal(errors.ErrorForbidden))
+	gm.Expect(err.Reason).To(gm.Equal("DELETE of feature_flag denied by admission hook rollout: flags are forever"))
+}
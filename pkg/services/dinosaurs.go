@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/cache"
 	"github.com/openshift-online/rh-trex/pkg/dao"
 	"github.com/openshift-online/rh-trex/pkg/db"
 	logger "github.com/openshift-online/rh-trex/pkg/logger"
@@ -14,6 +16,7 @@ import (
 // This flag will only be used in integration test to prove that the advisory lock works
 var DisableAdvisoryLock = false
 
+//go:generate mockgen -source=dinosaurs.go -destination=mocks/mock_dinosaurs.go -package=mocks
 type DinosaurService interface {
 	Get(ctx context.Context, id string) (*api.Dinosaur, *errors.ServiceError)
 	Create(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError)
@@ -29,11 +32,13 @@ type DinosaurService interface {
 	OnDelete(ctx context.Context, id string) error
 }
 
-func NewDinosaurService(lockFactory db.LockFactory, dinosaurDao dao.DinosaurDao, events EventService) DinosaurService {
+func NewDinosaurService(lockFactory db.LockFactory, sessionFactory *db.SessionFactory, dinosaurDao dao.DinosaurDao, events EventService, admission *admission.Chain, hooks *Hooks, cache *cache.Cache[api.Dinosaur]) DinosaurService {
 	return &sqlDinosaurService{
 		lockFactory: lockFactory,
 		dinosaurDao: dinosaurDao,
 		events:      events,
+		writer:      newKindWriter[api.Dinosaur](sessionFactory, admission, hooks),
+		cache:       cache,
 	}
 }
 
@@ -43,6 +48,10 @@ type sqlDinosaurService struct {
 	lockFactory db.LockFactory
 	dinosaurDao dao.DinosaurDao
 	events      EventService
+	// writer runs the admission and lifecycle hooks around the writes
+	writer kindWriter[api.Dinosaur]
+	// cache is nil unless dinosaurs are configured to be cached
+	cache *cache.Cache[api.Dinosaur]
 }
 
 func (s *sqlDinosaurService) OnUpsert(ctx context.Context, id string) error {
@@ -65,29 +74,32 @@ func (s *sqlDinosaurService) OnDelete(ctx context.Context, id string) error {
 }
 
 func (s *sqlDinosaurService) Get(ctx context.Context, id string) (*api.Dinosaur, *errors.ServiceError) {
-	dinosaur, err := s.dinosaurDao.Get(ctx, id)
-	if err != nil {
-		return nil, handleGetError("Dinosaur", "id", id, err)
-	}
-	return dinosaur, nil
+	return s.cache.Get(ctx, id, func() (*api.Dinosaur, *errors.ServiceError) {
+		dinosaur, err := s.dinosaurDao.Get(ctx, id)
+		if err != nil {
+			return nil, handleGetError("Dinosaur", "id", id, err)
+		}
+		return dinosaur, nil
+	})
 }
 
 func (s *sqlDinosaurService) Create(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
-	dinosaur, err := s.dinosaurDao.Create(ctx, dinosaur)
-	if err != nil {
-		return nil, handleCreateError("Dinosaur", err)
-	}
+	return s.writer.create(ctx, "Dinosaur", dinosaur, func(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
+		dinosaur, err := s.dinosaurDao.Create(ctx, dinosaur)
+		if err != nil {
+			return nil, handleCreateError("Dinosaur", err)
+		}
 
-	_, eErr := s.events.Create(ctx, &api.Event{
-		Source:    "Dinosaurs",
-		SourceID:  dinosaur.ID,
-		EventType: api.CreateEventType,
+		_, eErr := s.events.Create(ctx, &api.Event{
+			Source:    "Dinosaurs",
+			SourceID:  dinosaur.ID,
+			EventType: api.CreateEventType,
+		})
+		if eErr != nil {
+			return nil, handleCreateError("Dinosaur", err)
+		}
+		return dinosaur, nil
 	})
-	if eErr != nil {
-		return nil, handleCreateError("Dinosaur", err)
-	}
-
-	return dinosaur, nil
 }
 
 func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
@@ -112,38 +124,56 @@ func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *api.Dinosaur
 		return found, nil
 	}
 
+	old := *found
 	found.Species = dinosaur.Species
-	updated, err := s.dinosaurDao.Replace(ctx, found)
-	if err != nil {
-		return nil, handleUpdateError("Dinosaur", err)
-	}
+	return s.writer.update(ctx, "Dinosaur", found, func() (*api.Dinosaur, *errors.ServiceError) {
+		return &old, nil
+	}, func(ctx context.Context, found *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
+		updated, err := s.dinosaurDao.Replace(ctx, found)
+		if err != nil {
+			return nil, handleUpdateError("Dinosaur", err)
+		}
+		if err := s.cache.Invalidate(ctx, updated.ID); err != nil {
+			return nil, handleUpdateError("Dinosaur", err)
+		}
 
-	_, eErr := s.events.Create(ctx, &api.Event{
-		Source:    "Dinosaurs",
-		SourceID:  updated.ID,
-		EventType: api.UpdateEventType,
+		_, eErr := s.events.Create(ctx, &api.Event{
+			Source:    "Dinosaurs",
+			SourceID:  updated.ID,
+			EventType: api.UpdateEventType,
+		})
+		if eErr != nil {
+			return nil, handleUpdateError("Dinosaur", err)
+		}
+		return updated, nil
 	})
-	if eErr != nil {
-		return nil, handleUpdateError("Dinosaur", err)
-	}
-	return updated, nil
 }
 
 func (s *sqlDinosaurService) Delete(ctx context.Context, id string) *errors.ServiceError {
-	if err := s.dinosaurDao.Delete(ctx, id); err != nil {
-		return handleDeleteError("Dinosaur", errors.GeneralError("Unable to delete dinosaur: %s", err))
-	}
+	return s.writer.delete(ctx, "Dinosaur", func() (*api.Dinosaur, *errors.ServiceError) {
+		found, err := s.dinosaurDao.Get(ctx, id)
+		if err != nil {
+			return nil, handleGetError("Dinosaur", "id", id, err)
+		}
+		return found, nil
+	}, func(ctx context.Context) *errors.ServiceError {
+		if err := s.dinosaurDao.Delete(ctx, id); err != nil {
+			return handleDeleteError("Dinosaur", errors.GeneralError("Unable to delete dinosaur: %s", err))
+		}
+		if err := s.cache.Invalidate(ctx, id); err != nil {
+			return handleDeleteError("Dinosaur", err)
+		}
 
-	_, err := s.events.Create(ctx, &api.Event{
-		Source:    "Dinosaurs",
-		SourceID:  id,
-		EventType: api.DeleteEventType,
+		_, err := s.events.Create(ctx, &api.Event{
+			Source:    "Dinosaurs",
+			SourceID:  id,
+			EventType: api.DeleteEventType,
+		})
+		if err != nil {
+			return handleDeleteError("Dinosaur", err)
+		}
+		return nil
 	})
-	if err != nil {
-		return handleDeleteError("Dinosaur", err)
-	}
-
-	return nil
 }
 
 func (s *sqlDinosaurService) FindByIDs(ctx context.Context, ids []string) (api.DinosaurList, *errors.ServiceError) {
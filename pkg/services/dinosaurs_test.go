@@ -3,12 +3,20 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	gm "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 
+	"github.com/openshift-online/rh-trex/pkg/admission"
 	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/cache"
+	"github.com/openshift-online/rh-trex/pkg/clock"
+	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/dao/mocks"
 	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex/pkg/errors"
 )
 
 func TestDinosaurFindBySpecies(t *testing.T) {
@@ -16,7 +24,7 @@ func TestDinosaurFindBySpecies(t *testing.T) {
 
 	dinoDAO := mocks.NewDinosaurDao()
 	events := NewEventService(mocks.NewEventDao())
-	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), dinoDAO, events)
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), nil, dinoDAO, events, admission.NewChain(), NewHooks(), nil)
 
 	const Fukuisaurus = "Fukuisaurus"
 	const Seismosaurus = "Seismosaurus"
@@ -46,3 +54,41 @@ func TestDinosaurFindBySpecies(t *testing.T) {
 	gm.Expect(err).To(gm.BeNil())
 	gm.Expect(len(breviceratops)).To(gm.Equal(1))
 }
+
+// notifications records the cache invalidations sent
+type notifications []string
+
+func (n *notifications) NewListener(ctx context.Context, channel string, callback func(payload string)) {
+}
+
+func (n *notifications) Notify(ctx context.Context, channel, payload string) error {
+	*n = append(*n, channel+" "+payload)
+	return nil
+}
+
+func TestDinosaurCache(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinoDao := mocks.NewMockDinosaurDao(ctrl)
+	sent := &notifications{}
+	caches := cache.NewRegistry(&config.CacheConfig{Kinds: []string{"Dinosaur"}, Size: 10, TTL: time.Minute}, sent, clock.New())
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), nil, dinoDao, NewEventService(mocks.NewEventDao()), admission.NewChain(), NewHooks(), cache.For[api.Dinosaur](caches, "Dinosaur"))
+
+	// the dinosaur is read once
+	dinoDao.EXPECT().Get(gomock.Any(), "1").Return(&api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Stegosaurus"}, nil)
+	for i := 0; i < 3; i++ {
+		dinosaur, err := dinoService.Get(context.Background(), "1")
+		gm.Expect(err).To(gm.BeNil())
+		gm.Expect(dinosaur.Species).To(gm.Equal("Stegosaurus"))
+	}
+
+	// and again once deleted
+	dinoDao.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+	gm.Expect(dinoService.Delete(context.Background(), "1")).To(gm.BeNil())
+	gm.Expect(*sent).To(gm.Equal(notifications{"cache Dinosaur/1"}))
+
+	dinoDao.EXPECT().Get(gomock.Any(), "1").Return(nil, gorm.ErrRecordNotFound)
+	_, err := dinoService.Get(context.Background(), "1")
+	gm.Expect(err.Code).To(gm.Equal(errors.ErrorNotFound))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/openshift-online/rh-trex/pkg/errors"
 )
 
+//go:generate mockgen -source=event.go -destination=mocks/mock_event.go -package=mocks
 type EventService interface {
 	Get(ctx context.Context, id string) (*api.Event, *errors.ServiceError)
 	Create(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError)
@@ -5,6 +5,7 @@ import (
 	e "errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"gorm.io/gorm"
@@ -21,6 +22,7 @@ import (
 	"github.com/openshift-online/rh-trex/pkg/logger"
 )
 
+//go:generate mockgen -source=generic.go -destination=mocks/mock_generic.go -package=mocks
 type GenericService interface {
 	List(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError)
 }
@@ -52,7 +54,6 @@ type listContext struct {
 	resourceType     string
 	joins            map[string]dao.TableRelation
 	groupBy          []string
-	set              map[string]struct{}
 }
 
 func (s *sqlGenericService) newListContext(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*listContext, interface{}, *errors.ServiceError) {
@@ -76,6 +77,7 @@ func (s *sqlGenericService) newListContext(ctx context.Context, username string,
 		resourceList:     resourceList,
 		disallowedFields: &disallowedFields,
 		resourceType:     resourceTypeStr,
+		joins:            map[string]dao.TableRelation{},
 	}, reflect.New(resourceModel).Interface(), nil
 }
 
@@ -94,6 +96,9 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// restrict the list to its scope with "WHERE"(s)
+		s.buildScope,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -123,14 +128,20 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 type listBuilder func(*listContext, *dao.GenericDao) (finished bool, err *errors.ServiceError)
 
 func (s *sqlGenericService) buildPreload(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
-	listCtx.set = make(map[string]struct{})
-
-	for _, preload := range listCtx.args.Preloads {
-		listCtx.set[preload] = struct{}{}
-	}
-	// preload each table only once; struct{} doesn't occupy any additional space
+	// preload each relation only once; struct{} doesn't occupy any additional space
+	set := make(map[string]struct{})
 	for _, preload := range listCtx.args.Preloads {
-		(*d).Preload(preload)
+		// related resource names, e.g. "dinosaur" or "eggs", are preloaded by their association;
+		// anything else is handed to gorm as is
+		name := preload
+		if relation, ok := (*d).GetTableRelation(preload); ok {
+			name = relation.Name
+		}
+		if _, exists := set[name]; exists {
+			continue
+		}
+		set[name] = struct{}{}
+		(*d).Preload(name)
 	}
 	return false, nil
 }
@@ -148,6 +159,20 @@ func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao
 	return false, nil
 }
 
+func (s *sqlGenericService) buildScope(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	// the columns are sorted so the same scope always builds the same query
+	columns := make([]string, 0, len(listCtx.args.Scope))
+	for column := range listCtx.args.Scope {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	for _, column := range columns {
+		// prepend the table name to prevent "ambiguous" errors once related tables are joined
+		(*d).Where(fmt.Sprintf("%s.%s = ?", (*d).GetTableName(), column), []interface{}{listCtx.args.Scope[column]})
+	}
+	return false, nil
+}
+
 func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
 	if listCtx.args.Search == "" {
 		s.addJoins(listCtx, d)
@@ -188,11 +213,8 @@ func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao)
 
 // JOIN the tables that appear in the search string
 func (s *sqlGenericService) addJoins(listCtx *listContext, d *dao.GenericDao) {
+	// preloads are loaded by separate queries, so every searched relation must be joined here
 	for _, r := range listCtx.joins {
-		if _, ok := listCtx.set[r.ForeignTableName]; ok {
-			// skip already included preloads
-			continue
-		}
 		sql := fmt.Sprintf(
 			"LEFT JOIN %s ON %s.%s = %s.%s AND %s.deleted_at IS NULL",
 			r.ForeignTableName, r.ForeignTableName, r.ForeignColumnName, r.TableName, r.ColumnName, r.ForeignTableName)
@@ -2,6 +2,8 @@ package services
 
 import (
 	"context"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/openshift-online/rh-trex/pkg/dao"
@@ -10,9 +12,11 @@ import (
 	"github.com/onsi/gomega/types"
 	"github.com/yaacov/tree-search-language/pkg/tsl"
 
+	"github.com/openshift-online/rh-trex/pkg/admission"
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/db/db_session"
+	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
 	"github.com/openshift-online/rh-trex/pkg/errors"
 
 	. "github.com/onsi/gomega"
@@ -20,10 +24,7 @@ import (
 
 func TestSQLTranslation(t *testing.T) {
 	RegisterTestingT(t)
-	dbConfig := config.NewDatabaseConfig()
-	err := dbConfig.ReadFiles()
-	Expect(err).ToNot(HaveOccurred())
-	var dbFactory db.SessionFactory = db_session.NewProdFactory(dbConfig)
+	var dbFactory db.SessionFactory = db_session.NewMemoryFactory(config.NewDatabaseConfig())
 	defer dbFactory.Close()
 
 	g := dao.NewGenericDao(&dbFactory)
@@ -79,3 +80,103 @@ func TestSQLTranslation(t *testing.T) {
 		Expect(values).To(valuesReal)
 	}
 }
+
+func TestList(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = db_session.NewMemoryFactory(config.NewDatabaseConfig())
+	defer dbFactory.Close()
+
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), &dbFactory, dao.NewDinosaurDao(&dbFactory), NewEventService(dao.NewEventDao(&dbFactory)), admission.NewChain(), NewHooks(), nil)
+	genericService := NewGenericService(dao.NewGenericDao(&dbFactory))
+
+	for _, species := range []string{"Fukuisaurus", "Seismosaurus", "Fukuisaurus"} {
+		_, err := dinoService.Create(context.Background(), &api.Dinosaur{Species: species})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	list := []api.Dinosaur{}
+	args := &ListArguments{Page: 1, Size: 100, Search: "species = 'Fukuisaurus'", OrderBy: []string{"created_at desc"}}
+	paging, err := genericService.List(context.Background(), "", args, &list)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(paging.Total).To(Equal(int64(2)))
+	Expect(list).To(HaveLen(2))
+	for _, dino := range list {
+		Expect(dino.Species).To(Equal("Fukuisaurus"))
+	}
+}
+
+func TestListScope(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = db_session.NewMemoryFactory(config.NewDatabaseConfig())
+	defer dbFactory.Close()
+
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), &dbFactory, dao.NewDinosaurDao(&dbFactory), NewEventService(dao.NewEventDao(&dbFactory)), admission.NewChain(), NewHooks(), nil)
+	genericService := NewGenericService(dao.NewGenericDao(&dbFactory))
+
+	for _, species := range []string{"Fukuisaurus", "Seismosaurus", "Fukuisaurus", "O'Fukuisaurus"} {
+		_, err := dinoService.Create(context.Background(), &api.Dinosaur{Species: species})
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	list := []api.Dinosaur{}
+	args := &ListArguments{Page: 1, Size: 100, Search: "species != 'Seismosaurus'", Scope: map[string]interface{}{"species": "Fukuisaurus"}}
+	paging, err := genericService.List(context.Background(), "", args, &list)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(paging.Total).To(Equal(int64(2)))
+	Expect(list).To(HaveLen(2))
+
+	// searches can't get around the scope to list other rows
+	list = []api.Dinosaur{}
+	args = &ListArguments{Page: 1, Size: 100, Search: "species = 'Seismosaurus' or species = 'Fukuisaurus'", Scope: map[string]interface{}{"species": "Fukuisaurus"}}
+	paging, err = genericService.List(context.Background(), "", args, &list)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(paging.Total).To(Equal(int64(2)))
+	for _, dino := range list {
+		Expect(dino.Species).To(Equal("Fukuisaurus"))
+	}
+
+	// the scope is a parameter of the query, so its value can't change the query
+	list = []api.Dinosaur{}
+	args = &ListArguments{Page: 1, Size: 100, Scope: map[string]interface{}{"species": "O'Fukuisaurus"}}
+	paging, err = genericService.List(context.Background(), "", args, &list)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(paging.Total).To(Equal(int64(1)))
+	Expect(list[0].Species).To(Equal("O'Fukuisaurus"))
+}
+
+var (
+	sqlPropertyField = regexp.MustCompile(`properties ->> '[^']*'`)
+	sqlPasswordField = regexp.MustCompile(`\bpassword\b`)
+)
+
+// the seed corpus is checked in under testdata/fuzz
+func FuzzTreeWalkForSqlizer(f *testing.F) {
+	genericService := sqlGenericService{}
+	f.Fuzz(func(t *testing.T, search string) {
+		tslTree, err := tsl.ParseTSL(search)
+		if err != nil {
+			return
+		}
+		disallowedFields := map[string]string{"password": "password"}
+		listCtx := &listContext{disallowedFields: &disallowedFields}
+		_, sqlizer, serviceErr := genericService.treeWalkForSqlizer(listCtx, tslTree)
+		if serviceErr != nil {
+			return
+		}
+		sql, values, err := sqlizer.ToSql()
+		if err != nil {
+			return
+		}
+
+		fields := sqlPropertyField.ReplaceAllString(sql, "properties")
+		if strings.ContainsAny(fields, "'\";") {
+			t.Fatalf("SQL %q has values that aren't parameters", sql)
+		}
+		if placeholders := strings.Count(sql, "?"); placeholders != len(values) {
+			t.Fatalf("SQL %q has %d placeholders for %d values", sql, placeholders, len(values))
+		}
+		if sqlPasswordField.MatchString(fields) {
+			t.Fatalf("disallowed field reached SQL %q", sql)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// Hooks is the registry of the lifecycle hooks of the kinds, registered by kind with BeforeCreate,
+// AfterCreate and the like, e.g.
+//
+//	services.AfterCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+//		...
+//	})
+//
+// The services write through a kindWriter of their kind, which runs its hooks around the writes, after the
+// admission hooks, with the context of the write, so the hooks' own writes are in its transaction. A hook
+// failing aborts the write with its error and rolls the transaction back, undoing the write, its event and
+// the writes of the hooks before it too.
+type Hooks struct {
+	mu    sync.RWMutex
+	hooks map[hookKey][]hookFunc
+}
+
+type hookPoint int
+
+const (
+	beforeCreate hookPoint = iota
+	afterCreate
+	beforeUpdate
+	afterUpdate
+	beforeDelete
+	afterDelete
+)
+
+type hookKey struct {
+	kind  reflect.Type
+	point hookPoint
+}
+
+// hookFunc is a typed hook, called with the old and new values of its kind, either of which may be nil
+type hookFunc func(ctx context.Context, old, current interface{}) *errors.ServiceError
+
+func NewHooks() *Hooks {
+	return &Hooks{hooks: map[hookKey][]hookFunc{}}
+}
+
+// BeforeCreate registers a hook called with the object of kind T about to be created, which it may change
+func BeforeCreate[T any](h *Hooks, hook func(ctx context.Context, created *T) *errors.ServiceError) {
+	register[T](h, beforeCreate, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, current.(*T))
+	})
+}
+
+// AfterCreate registers a hook called with the object of kind T just created
+func AfterCreate[T any](h *Hooks, hook func(ctx context.Context, created *T) *errors.ServiceError) {
+	register[T](h, afterCreate, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, current.(*T))
+	})
+}
+
+// BeforeUpdate registers a hook called with the object of kind T before and after the update about to be
+// made, the latter of which it may change
+func BeforeUpdate[T any](h *Hooks, hook func(ctx context.Context, old, updated *T) *errors.ServiceError) {
+	register[T](h, beforeUpdate, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, old.(*T), current.(*T))
+	})
+}
+
+// AfterUpdate registers a hook called with the object of kind T before and after the update just made
+func AfterUpdate[T any](h *Hooks, hook func(ctx context.Context, old, updated *T) *errors.ServiceError) {
+	register[T](h, afterUpdate, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, old.(*T), current.(*T))
+	})
+}
+
+// BeforeDelete registers a hook called with the object of kind T about to be deleted
+func BeforeDelete[T any](h *Hooks, hook func(ctx context.Context, deleted *T) *errors.ServiceError) {
+	register[T](h, beforeDelete, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, old.(*T))
+	})
+}
+
+// AfterDelete registers a hook called with the object of kind T just deleted
+func AfterDelete[T any](h *Hooks, hook func(ctx context.Context, deleted *T) *errors.ServiceError) {
+	register[T](h, afterDelete, func(ctx context.Context, old, current interface{}) *errors.ServiceError {
+		return hook(ctx, old.(*T))
+	})
+}
+
+func register[T any](h *Hooks, point hookPoint, hook hookFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := hookKey{kind: reflect.TypeOf((*T)(nil)).Elem(), point: point}
+	h.hooks[key] = append(h.hooks[key], hook)
+}
+
+func hooksOf[T any](h *Hooks, point hookPoint) []hookFunc {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hooks[hookKey{kind: reflect.TypeOf((*T)(nil)).Elem(), point: point}]
+}
+
+// hasHooks tells whether any hook of kind T is registered at the points. The kindWriter reads the old object
+// of an update or a delete only when it is needed.
+func hasHooks[T any](h *Hooks, points ...hookPoint) bool {
+	for _, point := range points {
+		if len(hooksOf[T](h, point)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// runHooks runs the hooks of kind T registered at point, in the order they were registered, and stops at the
+// first failing
+func runHooks[T any](ctx context.Context, h *Hooks, point hookPoint, old, current *T) *errors.ServiceError {
+	for _, hook := range hooksOf[T](h, point) {
+		if err := hook(ctx, old, current); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/config"
+	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/db/db_session"
+	dbmocks "github.com/openshift-online/rh-trex/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+func TestDinosaurHooks(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinoDao := mocks.NewMockDinosaurDao(ctrl)
+	hooks := NewHooks()
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), nil, dinoDao, NewEventService(mocks.NewEventDao()), admission.NewChain(), hooks, nil)
+
+	var calls []string
+	BeforeCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		if dinosaur.Species == "Unicorn" {
+			return errors.Validation("unicorns aren't dinosaurs")
+		}
+		dinosaur.Species += "us"
+		return nil
+	})
+	AfterCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		calls = append(calls, "created "+dinosaur.Species)
+		return nil
+	})
+	BeforeUpdate(hooks, func(ctx context.Context, old, dinosaur *api.Dinosaur) *errors.ServiceError {
+		calls = append(calls, "updating "+old.Species+" to "+dinosaur.Species)
+		return nil
+	})
+	AfterDelete(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		calls = append(calls, "deleted "+dinosaur.Species)
+		return nil
+	})
+	// the hooks of the other kinds aren't run
+	BeforeCreate(hooks, func(ctx context.Context, resource *api.CustomResource) *errors.ServiceError {
+		return errors.GeneralError("not a dinosaur")
+	})
+
+	// an aborting hook keeps the write from the DAO
+	_, err := dinoService.Create(context.Background(), &api.Dinosaur{Species: "Unicorn"})
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Reason).To(gm.Equal("unicorns aren't dinosaurs"))
+
+	dinosaur := &api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Stegosaur"}
+	dinoDao.EXPECT().Create(gomock.Any(), dinosaur).Return(dinosaur, nil)
+	_, err = dinoService.Create(context.Background(), dinosaur)
+	gm.Expect(err).To(gm.BeNil())
+
+	dinoDao.EXPECT().Get(gomock.Any(), "1").Return(&api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Stegosaurus"}, nil)
+	dinoDao.EXPECT().Replace(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, error) {
+		return dinosaur, nil
+	})
+	_, err = dinoService.Replace(context.Background(), &api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Triceratops"})
+	gm.Expect(err).To(gm.BeNil())
+
+	// the dinosaur deleted is read for the delete hook
+	dinoDao.EXPECT().Get(gomock.Any(), "1").Return(&api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Triceratops"}, nil)
+	dinoDao.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+	gm.Expect(dinoService.Delete(context.Background(), "1")).To(gm.BeNil())
+
+	gm.Expect(calls).To(gm.Equal([]string{
+		"created Stegosaurus",
+		"updating Stegosaurus to Triceratops",
+		"deleted Triceratops",
+	}))
+}
+
+func TestAfterHookAborts(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	ctrl := gomock.NewController(t)
+	dinoDao := mocks.NewMockDinosaurDao(ctrl)
+	hooks := NewHooks()
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), nil, dinoDao, NewEventService(mocks.NewEventDao()), admission.NewChain(), hooks, nil)
+
+	AfterCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		return errors.Conflict("%s is taken", dinosaur.Species)
+	})
+
+	// the write is made and the hook's error returned, TestAfterHookRollsBack checks the rollback
+	dinosaur := &api.Dinosaur{Meta: api.Meta{ID: "1"}, Species: "Stegosaurus"}
+	dinoDao.EXPECT().Create(gomock.Any(), dinosaur).Return(dinosaur, nil)
+	created, err := dinoService.Create(context.Background(), dinosaur)
+	gm.Expect(created).To(gm.BeNil())
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Reason).To(gm.Equal("Stegosaurus is taken"))
+
+	// without delete hooks the dinosaur isn't read
+	dinoDao.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+	gm.Expect(dinoService.Delete(context.Background(), "1")).To(gm.BeNil())
+}
+
+func TestAfterHookRollsBack(t *testing.T) {
+	gm.RegisterTestingT(t)
+	var dbFactory db.SessionFactory = db_session.NewMemoryFactory(config.NewDatabaseConfig())
+	defer dbFactory.Close()
+
+	hooks := NewHooks()
+	dinoService := NewDinosaurService(dbmocks.NewMockAdvisoryLockFactory(), &dbFactory, dao.NewDinosaurDao(&dbFactory), NewEventService(dao.NewEventDao(&dbFactory)), admission.NewChain(), hooks, nil)
+
+	BeforeCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		// the writes of the hooks are in the transaction too
+		_, err := dao.NewEventDao(&dbFactory).Create(ctx, &api.Event{Source: "Hooks", SourceID: dinosaur.Species, EventType: api.CreateEventType})
+		if err != nil {
+			return errors.GeneralError("%s", err)
+		}
+		return nil
+	})
+	AfterCreate(hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		if dinosaur.Species == "Unicorn" {
+			return errors.Validation("unicorns aren't dinosaurs")
+		}
+		return nil
+	})
+
+	_, err := dinoService.Create(context.Background(), &api.Dinosaur{Species: "Unicorn"})
+	gm.Expect(err).NotTo(gm.BeNil())
+	gm.Expect(err.Reason).To(gm.Equal("unicorns aren't dinosaurs"))
+
+	// neither the dinosaur, its event nor the hook's event were committed
+	count := func(table string) int64 {
+		var total int64
+		gm.Expect(dbFactory.New(context.Background()).Table(table).Count(&total).Error).To(gm.Succeed())
+		return total
+	}
+	gm.Expect(count("dinosaurs")).To(gm.Equal(int64(0)))
+	gm.Expect(count("events")).To(gm.Equal(int64(0)))
+
+	_, err = dinoService.Create(context.Background(), &api.Dinosaur{Species: "Stegosaurus"})
+	gm.Expect(err).To(gm.BeNil())
+	gm.Expect(count("dinosaurs")).To(gm.Equal(int64(1)))
+	gm.Expect(count("events")).To(gm.Equal(int64(2)))
+}
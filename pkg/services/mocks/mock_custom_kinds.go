@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: custom_kinds.go
+//
+// Generated by this command:
+//
+//	mockgen -source=custom_kinds.go -destination=mocks/mock_custom_kinds.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	errors "github.com/openshift-online/rh-trex/pkg/errors"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomKindService is a mock of CustomKindService interface.
+type MockCustomKindService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomKindServiceMockRecorder
+}
+
+// MockCustomKindServiceMockRecorder is the mock recorder for MockCustomKindService.
+type MockCustomKindServiceMockRecorder struct {
+	mock *MockCustomKindService
+}
+
+// NewMockCustomKindService creates a new mock instance.
+func NewMockCustomKindService(ctrl *gomock.Controller) *MockCustomKindService {
+	mock := &MockCustomKindService{ctrl: ctrl}
+	mock.recorder = &MockCustomKindServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomKindService) EXPECT() *MockCustomKindServiceMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockCustomKindService) All(ctx context.Context) (api.CustomKindList, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx)
+	ret0, _ := ret[0].(api.CustomKindList)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// All indicates an expected call of All.
+func (mr *MockCustomKindServiceMockRecorder) All(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockCustomKindService)(nil).All), ctx)
+}
+
+// Create mocks base method.
+func (m *MockCustomKindService) Create(ctx context.Context, kind *api.CustomKind) (*api.CustomKind, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, kind)
+	ret0, _ := ret[0].(*api.CustomKind)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomKindServiceMockRecorder) Create(ctx, kind any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomKindService)(nil).Create), ctx, kind)
+}
+
+// Delete mocks base method.
+func (m *MockCustomKindService) Delete(ctx context.Context, name string) *errors.ServiceError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(*errors.ServiceError)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomKindServiceMockRecorder) Delete(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomKindService)(nil).Delete), ctx, name)
+}
+
+// Get mocks base method.
+func (m *MockCustomKindService) Get(ctx context.Context, name string) (*api.CustomKind, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret0, _ := ret[0].(*api.CustomKind)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCustomKindServiceMockRecorder) Get(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCustomKindService)(nil).Get), ctx, name)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: custom_resources.go
+//
+// Generated by this command:
+//
+//	mockgen -source=custom_resources.go -destination=mocks/mock_custom_resources.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	errors "github.com/openshift-online/rh-trex/pkg/errors"
+	services "github.com/openshift-online/rh-trex/pkg/services"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomResourceService is a mock of CustomResourceService interface.
+type MockCustomResourceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomResourceServiceMockRecorder
+}
+
+// MockCustomResourceServiceMockRecorder is the mock recorder for MockCustomResourceService.
+type MockCustomResourceServiceMockRecorder struct {
+	mock *MockCustomResourceService
+}
+
+// NewMockCustomResourceService creates a new mock instance.
+func NewMockCustomResourceService(ctrl *gomock.Controller) *MockCustomResourceService {
+	mock := &MockCustomResourceService{ctrl: ctrl}
+	mock.recorder = &MockCustomResourceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomResourceService) EXPECT() *MockCustomResourceServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomResourceService) Create(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, resource)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomResourceServiceMockRecorder) Create(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomResourceService)(nil).Create), ctx, resource)
+}
+
+// Delete mocks base method.
+func (m *MockCustomResourceService) Delete(ctx context.Context, kind, id string) *errors.ServiceError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, kind, id)
+	ret0, _ := ret[0].(*errors.ServiceError)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomResourceServiceMockRecorder) Delete(ctx, kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomResourceService)(nil).Delete), ctx, kind, id)
+}
+
+// Get mocks base method.
+func (m *MockCustomResourceService) Get(ctx context.Context, kind, id string) (*api.CustomResource, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, kind, id)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCustomResourceServiceMockRecorder) Get(ctx, kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCustomResourceService)(nil).Get), ctx, kind, id)
+}
+
+// List mocks base method.
+func (m *MockCustomResourceService) List(ctx context.Context, kind string, args *services.ListArguments) (api.CustomResourceList, *api.PagingMeta, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, kind, args)
+	ret0, _ := ret[0].(api.CustomResourceList)
+	ret1, _ := ret[1].(*api.PagingMeta)
+	ret2, _ := ret[2].(*errors.ServiceError)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockCustomResourceServiceMockRecorder) List(ctx, kind, args any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCustomResourceService)(nil).List), ctx, kind, args)
+}
+
+// OnDelete mocks base method.
+func (m *MockCustomResourceService) OnDelete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnDelete indicates an expected call of OnDelete.
+func (mr *MockCustomResourceServiceMockRecorder) OnDelete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDelete", reflect.TypeOf((*MockCustomResourceService)(nil).OnDelete), ctx, id)
+}
+
+// OnUpsert mocks base method.
+func (m *MockCustomResourceService) OnUpsert(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnUpsert", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnUpsert indicates an expected call of OnUpsert.
+func (mr *MockCustomResourceServiceMockRecorder) OnUpsert(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnUpsert", reflect.TypeOf((*MockCustomResourceService)(nil).OnUpsert), ctx, id)
+}
+
+// Replace mocks base method.
+func (m *MockCustomResourceService) Replace(ctx context.Context, resource *api.CustomResource) (*api.CustomResource, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, resource)
+	ret0, _ := ret[0].(*api.CustomResource)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockCustomResourceServiceMockRecorder) Replace(ctx, resource any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockCustomResourceService)(nil).Replace), ctx, resource)
+}
@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dinosaurs.go
+//
+// Generated by this command:
+//
+//	mockgen -source=dinosaurs.go -destination=mocks/mock_dinosaurs.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	errors "github.com/openshift-online/rh-trex/pkg/errors"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDinosaurService is a mock of DinosaurService interface.
+type MockDinosaurService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDinosaurServiceMockRecorder
+}
+
+// MockDinosaurServiceMockRecorder is the mock recorder for MockDinosaurService.
+type MockDinosaurServiceMockRecorder struct {
+	mock *MockDinosaurService
+}
+
+// NewMockDinosaurService creates a new mock instance.
+func NewMockDinosaurService(ctrl *gomock.Controller) *MockDinosaurService {
+	mock := &MockDinosaurService{ctrl: ctrl}
+	mock.recorder = &MockDinosaurServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDinosaurService) EXPECT() *MockDinosaurServiceMockRecorder {
+	return m.recorder
+}
+
+// All mocks base method.
+func (m *MockDinosaurService) All(ctx context.Context) (api.DinosaurList, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "All", ctx)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// All indicates an expected call of All.
+func (mr *MockDinosaurServiceMockRecorder) All(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "All", reflect.TypeOf((*MockDinosaurService)(nil).All), ctx)
+}
+
+// Create mocks base method.
+func (m *MockDinosaurService) Create(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, dinosaur)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDinosaurServiceMockRecorder) Create(ctx, dinosaur any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDinosaurService)(nil).Create), ctx, dinosaur)
+}
+
+// Delete mocks base method.
+func (m *MockDinosaurService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(*errors.ServiceError)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockDinosaurServiceMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDinosaurService)(nil).Delete), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockDinosaurService) FindByIDs(ctx context.Context, ids []string) (api.DinosaurList, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockDinosaurServiceMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockDinosaurService)(nil).FindByIDs), ctx, ids)
+}
+
+// FindBySpecies mocks base method.
+func (m *MockDinosaurService) FindBySpecies(ctx context.Context, species string) (api.DinosaurList, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySpecies", ctx, species)
+	ret0, _ := ret[0].(api.DinosaurList)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// FindBySpecies indicates an expected call of FindBySpecies.
+func (mr *MockDinosaurServiceMockRecorder) FindBySpecies(ctx, species any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySpecies", reflect.TypeOf((*MockDinosaurService)(nil).FindBySpecies), ctx, species)
+}
+
+// Get mocks base method.
+func (m *MockDinosaurService) Get(ctx context.Context, id string) (*api.Dinosaur, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDinosaurServiceMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDinosaurService)(nil).Get), ctx, id)
+}
+
+// OnDelete mocks base method.
+func (m *MockDinosaurService) OnDelete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnDelete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnDelete indicates an expected call of OnDelete.
+func (mr *MockDinosaurServiceMockRecorder) OnDelete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnDelete", reflect.TypeOf((*MockDinosaurService)(nil).OnDelete), ctx, id)
+}
+
+// OnUpsert mocks base method.
+func (m *MockDinosaurService) OnUpsert(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OnUpsert", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OnUpsert indicates an expected call of OnUpsert.
+func (mr *MockDinosaurServiceMockRecorder) OnUpsert(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnUpsert", reflect.TypeOf((*MockDinosaurService)(nil).OnUpsert), ctx, id)
+}
+
+// Replace mocks base method.
+func (m *MockDinosaurService) Replace(ctx context.Context, dinosaur *api.Dinosaur) (*api.Dinosaur, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", ctx, dinosaur)
+	ret0, _ := ret[0].(*api.Dinosaur)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockDinosaurServiceMockRecorder) Replace(ctx, dinosaur any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockDinosaurService)(nil).Replace), ctx, dinosaur)
+}
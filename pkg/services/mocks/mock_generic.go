@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: generic.go
+//
+// Generated by this command:
+//
+//	mockgen -source=generic.go -destination=mocks/mock_generic.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	api "github.com/openshift-online/rh-trex/pkg/api"
+	errors "github.com/openshift-online/rh-trex/pkg/errors"
+	services "github.com/openshift-online/rh-trex/pkg/services"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGenericService is a mock of GenericService interface.
+type MockGenericService struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenericServiceMockRecorder
+}
+
+// MockGenericServiceMockRecorder is the mock recorder for MockGenericService.
+type MockGenericServiceMockRecorder struct {
+	mock *MockGenericService
+}
+
+// NewMockGenericService creates a new mock instance.
+func NewMockGenericService(ctrl *gomock.Controller) *MockGenericService {
+	mock := &MockGenericService{ctrl: ctrl}
+	mock.recorder = &MockGenericServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenericService) EXPECT() *MockGenericServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockGenericService) List(ctx context.Context, username string, args *services.ListArguments, resourceList any) (*api.PagingMeta, *errors.ServiceError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, username, args, resourceList)
+	ret0, _ := ret[0].(*api.PagingMeta)
+	ret1, _ := ret[1].(*errors.ServiceError)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockGenericServiceMockRecorder) List(ctx, username, args, resourceList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockGenericService)(nil).List), ctx, username, args, resourceList)
+}
@@ -15,6 +15,9 @@ type ListArguments struct {
 	Search   string
 	OrderBy  []string
 	Fields   []string
+	// Scope restricts the list to the rows whose columns equal the given values, e.g. the parent of a nested route.
+	// It is set by handlers, never from the url query
+	Scope map[string]interface{}
 }
 
 // ~65500 is the maximum number of parameters that can be provided to a postgres WHERE IN clause
@@ -45,6 +48,13 @@ func NewListArguments(params url.Values) *ListArguments {
 	if v := strings.Trim(params.Get("orderBy"), " "); v != "" {
 		listArgs.OrderBy = strings.Split(v, ",")
 	}
+	if v := strings.Trim(params.Get("preload"), " "); v != "" {
+		for _, preload := range strings.Split(v, ",") {
+			if preload = strings.Trim(preload, " "); preload != "" {
+				listArgs.Preloads = append(listArgs.Preloads, preload)
+			}
+		}
+	}
 	if v := strings.Trim(params.Get("fields"), " "); v != "" {
 		fields := strings.Split(v, ",")
 		idNotPresent := true
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex/pkg/admission"
+	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// kindWriter makes the writes of the services of kind T. It checks each write with the admission hooks, then runs
+// it between the lifecycle hooks of T, so every kind's service calls the hooks alike by writing through one. The
+// write itself, the DAO call and its event, is given by the service. The write and the hooks are made in one
+// transaction, with the context passed to the write, so a failing hook rolls back the write, its event and the
+// writes of the hooks before it.
+type kindWriter[T any] struct {
+	sessionFactory *db.SessionFactory
+	admission      *admission.Chain
+	hooks          *Hooks
+}
+
+func newKindWriter[T any](sessionFactory *db.SessionFactory, admission *admission.Chain, hooks *Hooks) kindWriter[T] {
+	return kindWriter[T]{sessionFactory: sessionFactory, admission: admission, hooks: hooks}
+}
+
+// create creates created, an object of kind, with write
+func (w kindWriter[T]) create(ctx context.Context, kind string, created *T, write func(ctx context.Context, created *T) (*T, *errors.ServiceError)) (*T, *errors.ServiceError) {
+	if err := w.admission.Admit(ctx, kind, admission.Create, created, nil); err != nil {
+		return nil, err
+	}
+
+	err := w.transaction(ctx, kind, func(ctx context.Context) *errors.ServiceError {
+		if err := runHooks(ctx, w.hooks, beforeCreate, nil, created); err != nil {
+			return err
+		}
+		var err *errors.ServiceError
+		if created, err = write(ctx, created); err != nil {
+			return err
+		}
+		return runHooks(ctx, w.hooks, afterCreate, nil, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// update updates updated, an object of kind, with write. old reads the object before the update, only when
+// the hooks need it.
+func (w kindWriter[T]) update(ctx context.Context, kind string, updated *T, old func() (*T, *errors.ServiceError), write func(ctx context.Context, updated *T) (*T, *errors.ServiceError)) (*T, *errors.ServiceError) {
+	var previous *T
+	if w.admission.Handles(kind, admission.Update) || hasHooks[T](w.hooks, beforeUpdate, afterUpdate) {
+		var err *errors.ServiceError
+		if previous, err = old(); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.admission.Admit(ctx, kind, admission.Update, updated, previous); err != nil {
+		return nil, err
+	}
+
+	err := w.transaction(ctx, kind, func(ctx context.Context) *errors.ServiceError {
+		if err := runHooks(ctx, w.hooks, beforeUpdate, previous, updated); err != nil {
+			return err
+		}
+		var err *errors.ServiceError
+		if updated, err = write(ctx, updated); err != nil {
+			return err
+		}
+		return runHooks(ctx, w.hooks, afterUpdate, previous, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// delete deletes an object of kind with write. deleted reads the object, only when the hooks need it.
+func (w kindWriter[T]) delete(ctx context.Context, kind string, deleted func() (*T, *errors.ServiceError), write func(ctx context.Context) *errors.ServiceError) *errors.ServiceError {
+	var found *T
+	if w.admission.Handles(kind, admission.Delete) || hasHooks[T](w.hooks, beforeDelete, afterDelete) {
+		var err *errors.ServiceError
+		if found, err = deleted(); err != nil {
+			return err
+		}
+	}
+	if err := w.admission.Admit(ctx, kind, admission.Delete, nil, found); err != nil {
+		return err
+	}
+
+	return w.transaction(ctx, kind, func(ctx context.Context) *errors.ServiceError {
+		if err := runHooks(ctx, w.hooks, beforeDelete, found, nil); err != nil {
+			return err
+		}
+		if err := write(ctx); err != nil {
+			return err
+		}
+		return runHooks(ctx, w.hooks, afterDelete, found, nil)
+	})
+}
+
+// transaction runs fn in the transaction of the write, rolled back when fn fails
+func (w kindWriter[T]) transaction(ctx context.Context, kind string, fn func(ctx context.Context) *errors.ServiceError) *errors.ServiceError {
+	var svcErr *errors.ServiceError
+	err := db.Transaction(ctx, w.sessionFactory, func(ctx context.Context) error {
+		if svcErr = fn(ctx); svcErr != nil {
+			// a nil *errors.ServiceError isn't a nil error
+			return svcErr
+		}
+		return nil
+	})
+	if svcErr != nil {
+		return svcErr
+	}
+	if err != nil {
+		return errors.GeneralError("Unable to commit the write of the %s: %s", kind, err)
+	}
+	return nil
+}
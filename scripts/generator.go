@@ -1,18 +1,34 @@
 package main
 
 import (
-	"bufio"
+	"bytes"
 	"flag"
 	"fmt"
-	"github.com/spf13/pflag"
+	"go/format"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
+
+	"github.com/jinzhu/inflection"
+	"github.com/spf13/pflag"
+	"gorm.io/gorm/schema"
+
+	"github.com/openshift-online/rh-trex/pkg/identity"
 )
 
 var (
-	kind string = "Asteroid"
+	kind       string = "Asteroid"
+	belongsTo  string = ""
+	hasMany    bool   = false
+	dryRun     bool   = false
+	force      bool   = false
+	outputRoot string = "."
+	remove     bool   = false
+	dropTable  bool   = false
 )
 
 func init() {
@@ -21,66 +37,441 @@ func init() {
 	flags.AddGoFlagSet(flag.CommandLine)
 
 	flags.StringVar(&kind, "kind", kind, "the name of the kind.  e.g Account or User")
+	flags.StringVar(&belongsTo, "belongs-to", belongsTo, "the name of an existing parent kind.  e.g Dinosaur for --kind Egg")
+	flags.BoolVar(&hasMany, "has-many", hasMany, "with --belongs-to, also add a has-many association to the parent kind.  e.g. Dinosaur.Eggs")
+	flags.BoolVar(&dryRun, "dry-run", dryRun, "print the files that would be created or changed, with diffs, without writing anything")
+	flags.BoolVar(&force, "force", force, "overwrite generated files that already exist")
+	flags.StringVar(&outputRoot, "output-root", outputRoot, "the repository to generate into.  e.g. the root of a fork; templates are always read from ./templates")
+	flags.BoolVar(&remove, "remove", remove, "remove a previously generated kind: its generated files and registrations")
+	flags.BoolVar(&dropTable, "drop-table", dropTable, "with --remove, keep the kind's migration and add one that drops its table")
 }
 
 func main() {
 	// Parse flags
 	pflag.Parse()
 
-	templates := []string{
-		"api",
-		"dao",
-		"services",
-		"mock",
-		"migration",
-		"test",
+	run := generate
+	if remove {
+		run = removeKind
+	}
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "generator: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+var templates = []string{
+	"api",
+	"presenters",
+	"dao",
+	"services",
+	"services-test",
+	"handlers",
+	"dao-gomock",
+	"services-gomock",
+	"migration",
+	"factories",
+	"test",
+	"openapi",
+	"proto",
+	"rpc",
+}
+
+// outputPath is where the file rendered from templates/generate-<nm>.txt goes, relative to --output-root
+func outputPath(nm string, k myWriter) string {
+	var outPath string
+
+	if strings.HasSuffix(nm, "-gomock") {
+		// what mockgen writes for the go:generate directive in the dao and service
+		outPath = fmt.Sprintf("pkg/%s/mocks/mock_%s.go", strings.TrimSuffix(nm, "-gomock"), k.KindLowerSingular)
+	} else if strings.Contains(nm, "migration") {
+		outPath = fmt.Sprintf("pkg/db/migrations/%s_add_%s.go", k.ID, k.KindLowerPlural)
+	} else if strings.Contains(nm, "services-test") {
+		outPath = fmt.Sprintf("pkg/services/%s_test.go", k.KindLowerPlural)
+	} else if strings.Contains(nm, "factories") {
+		outPath = fmt.Sprintf("test/factories_%s.go", k.KindLowerSingular)
+	} else if strings.Contains(nm, "test") {
+		outPath = fmt.Sprintf("test/integration/%s_test.go", k.KindLowerPlural)
+	} else if strings.Contains(nm, "openapi") {
+		outPath = fmt.Sprintf("openapi/openapi.%s.yaml", k.KindLowerPlural)
+	} else if strings.Contains(nm, "proto") {
+		outPath = fmt.Sprintf("pkg/api/pb/%s.proto", k.KindLowerPlural)
+	} else if strings.Contains(nm, "presenters") {
+		outPath = fmt.Sprintf("pkg/api/presenters/%s.go", k.KindLowerSingular)
+	} else if strings.Contains(nm, "api") {
+		outPath = fmt.Sprintf("pkg/api/%s_types.go", k.KindLowerSingular)
+	} else {
+		outPath = fmt.Sprintf("pkg/%s/%s.go", nm, k.KindLowerSingular)
+	}
+
+	return outPath
+}
+
+// change is a single file the generator will write. Nothing is written until every change
+// has been rendered, so a template error never leaves a half generated kind behind.
+type change struct {
+	Path     string
+	Contents []byte
+	// Generated files are owned by the generator and are refused if they exist, unless --force.
+	// Registrations edit existing files in place and are always applied.
+	Generated bool
+	// Delete removes Path instead of writing Contents
+	Delete bool
+}
+
+func generate() error {
+	if hasMany && belongsTo == "" {
+		return fmt.Errorf("--has-many requires --belongs-to")
+	}
+
+	k, err := newWriter(kind, belongsTo, hasMany)
+	if err != nil {
+		return err
+	}
+	if k.Parent != "" {
+		if _, err := findStruct(k.Parent); err != nil {
+			return fmt.Errorf("--belongs-to: %v", err)
+		}
 	}
 
+	var changes []change
 	for _, nm := range templates {
 		path := fmt.Sprintf("templates/generate-%s.txt", nm)
 		contents, err := os.ReadFile(path)
 		if err != nil {
-			panic(err)
-			return
+			return err
 		}
 
 		kindTmpl, err := template.New(nm).Parse(string(contents))
 		if err != nil {
-			panic(err)
+			return err
+		}
+
+		outPath := filepath.Join(outputRoot, outputPath(nm, k))
+
+		var buf bytes.Buffer
+		err = kindTmpl.Execute(&buf, k)
+		if err != nil {
+			return fmt.Errorf("executing template %s: %v", path, err)
+		}
+
+		formatted, err := formatSource(outPath, buf.Bytes())
+		if err != nil {
+			return err
 		}
+		changes = append(changes, change{Path: outPath, Contents: formatted, Generated: true})
+	}
 
-		k := myWriter{
-			Kind:              kind,
-			KindLowerPlural:   strings.ToLower(fmt.Sprintf("%ss", kind)),
-			KindLowerSingular: strings.ToLower(kind),
+	// registrations may touch the same file more than once, so each one builds on the last
+	pending := map[string][]byte{}
+	for _, r := range registrations {
+		c, err := register(r, k, pending)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			pending[c.Path] = c.Contents
+			changes = append(changes, *c)
+		}
+	}
+
+	if k.HasMany {
+		c, err := addHasMany(k)
+		if err != nil {
+			return err
 		}
+		if c != nil {
+			changes = append(changes, *c)
+		}
+	}
+
+	return apply(changes)
+}
 
-		now := time.Now()
-		k.ID = fmt.Sprintf("%d%s%s%s%s", now.Year(), datePad(int(now.Month())), datePad(now.Day()), datePad(now.Hour()), datePad(now.Minute()))
+// apply checks, previews or writes the planned changes
+func apply(changes []change) error {
+	changes = squash(changes)
+
+	var conflicts []string
+	for _, c := range changes {
+		if c.Generated && !c.Delete && exists(c.Path) && !force {
+			conflicts = append(conflicts, c.Path)
+		}
+	}
 
-		var outPath string
+	if dryRun {
+		for _, c := range changes {
+			if err := printChange(c); err != nil {
+				return err
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("refusing to overwrite existing files, use --force to overwrite:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	if dryRun {
+		return nil
+	}
 
-		if strings.Contains(nm, "mock") {
-			outPath = fmt.Sprintf("pkg/dao/mocks/%s.go", k.KindLowerSingular)
-		} else if strings.Contains(nm, "migration") {
-			outPath = fmt.Sprintf("pkg/db/migrations/%s_add_%s.go", k.ID, k.KindLowerPlural)
-		} else if strings.Contains(nm, "test") {
-			outPath = fmt.Sprintf("test/integration/%s_test.go", k.KindLowerPlural)
-		} else {
-			outPath = fmt.Sprintf("pkg/%s/%s.go", nm, k.KindLowerSingular)
+	for _, c := range changes {
+		if c.Delete {
+			if err := os.Remove(c.Path); err != nil {
+				return err
+			}
+			fmt.Printf("deleted %s\n", c.Path)
+			continue
 		}
+		if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(c.Path, c.Contents, 0644); err != nil {
+			return err
+		}
+		fmt.Println(c.Path)
+	}
+	return nil
+}
+
+// removeKind plans the removal of everything generate created for a kind. The parent and
+// has-many association are read back from the api structs, so only --kind is needed.
+func removeKind() error {
+	k, err := newWriter(kind, "", false)
+	if err != nil {
+		return err
+	}
 
-		f, err := os.Create(outPath)
-		defer f.Close()
+	typesPath := filepath.Join(outputRoot, outputPath("api", k))
+	if contents, err := os.ReadFile(typesPath); err == nil {
+		if parent := belongsToParent(k.Kind, contents); parent != "" {
+			k, err = newWriter(kind, parent, false)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if k.Parent != "" {
+		if path, err := findStruct(k.Parent); err == nil {
+			contents, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			k.HasMany = hasManyField(k).Match(contents)
+		}
+	}
 
-		w := bufio.NewWriter(f)
-		err = kindTmpl.Execute(w, k)
+	children, err := findChildren(k)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 && !force {
+		return fmt.Errorf("%s is the parent of %s, remove those first or use --force", k.Kind, strings.Join(children, ", "))
+	}
+
+	var changes []change
+	for _, nm := range templates {
+		if nm == "migration" && dropTable {
+			// databases that ran the add migration need it in the list to roll back the drop
+			continue
+		}
+		path := filepath.Join(outputRoot, outputPath(nm, k))
+		if exists(path) {
+			changes = append(changes, change{Path: path, Generated: true, Delete: true})
+		}
+	}
+	// and what make generate/grpc compiled from the proto, and the hand-written DAO fake of the kinds
+	// generated before the gomock ones replaced it
+	for _, path := range []string{
+		filepath.Join("pkg/api/pb", k.KindLowerPlural+".pb.go"),
+		filepath.Join("pkg/api/pb", k.KindLowerPlural+"_grpc.pb.go"),
+		filepath.Join("pkg/dao/mocks", k.KindLowerSingular+".go"),
+	} {
+		path = filepath.Join(outputRoot, path)
+		if exists(path) {
+			changes = append(changes, change{Path: path, Generated: true, Delete: true})
+		}
+	}
+
+	pending := map[string][]byte{}
+	for _, r := range registrations {
+		if r.Marker == migrationsMarker && dropTable {
+			continue
+		}
+		c, err := unregister(r, k, pending)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			pending[c.Path] = c.Contents
+			changes = append(changes, *c)
+		}
+	}
+
+	if k.HasMany {
+		c, err := removeHasMany(k)
+		if err != nil {
+			return err
+		}
+		changes = append(changes, *c)
+	}
+
+	if len(changes) == 0 {
+		return fmt.Errorf("nothing to remove, %s was not generated in %s", k.Kind, outputRoot)
+	}
+
+	if dropTable {
+		drop := k
+		drop.ID = migrationID(time.Now())
+		if drop.ID == k.ID {
+			// migration IDs must be unique, and these are only minute resolution
+			drop.ID += "01"
+		}
+		contents, err := os.ReadFile("templates/generate-drop-migration.txt")
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New("drop-migration").Parse(string(contents))
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, drop); err != nil {
+			return err
+		}
+		path := filepath.Join(outputRoot, fmt.Sprintf("pkg/db/migrations/%s_drop_%s.go", drop.ID, k.KindLowerPlural))
+		formatted, err := formatSource(path, buf.Bytes())
 		if err != nil {
-			panic(err)
+			return err
+		}
+		changes = append(changes, change{Path: path, Contents: formatted, Generated: true})
+
+		c, err := register(registration{
+			Path:     migrationStructsPath,
+			Marker:   migrationsMarker,
+			Template: "drop{{.KindPlural}}(),\n",
+		}, k, pending)
+		if err != nil {
+			return err
+		}
+		if c != nil {
+			changes = append(changes, *c)
+		}
+	} else if !dryRun {
+		fmt.Fprintf(os.Stderr, "note: the %s table is left in databases that already ran its migration, use --drop-table to drop it\n", k.KindTable)
+	}
+
+	return apply(changes)
+}
+
+// belongsToParent finds the Parent of a generated kind from its ParentID and Parent fields
+func belongsToParent(kind string, contents []byte) string {
+	loc := structDecl(kind).FindSubmatchIndex(contents)
+	if loc == nil {
+		return ""
+	}
+	body := string(contents[loc[2]:loc[3]])
+	for _, m := range regexp.MustCompile(`(?m)^\s*(\w+)ID\s+string\s*$`).FindAllStringSubmatch(body, -1) {
+		association := regexp.MustCompile(fmt.Sprintf(`(?m)^\s*%s\s+\*%s\s*$`, m[1], m[1]))
+		if association.MatchString(body) {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// findChildren lists the kinds that belong to k
+func findChildren(k myWriter) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(outputRoot, "pkg/api/*.go"))
+	if err != nil {
+		return nil, err
+	}
+	decl := regexp.MustCompile(`(?m)^type (\w+) struct {`)
+	var children []string
+	for _, path := range files {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range decl.FindAllSubmatch(contents, -1) {
+			name := string(m[1])
+			if name != k.Kind && belongsToParent(name, contents) == k.Kind {
+				children = append(children, name)
+			}
+		}
+	}
+	return children, nil
+}
+
+// squash keeps only the last change to each path
+func squash(changes []change) []change {
+	last := map[string]int{}
+	for i, c := range changes {
+		last[c.Path] = i
+	}
+	var squashed []change
+	for i, c := range changes {
+		if last[c.Path] != i {
+			continue
+		}
+		squashed = append(squashed, c)
+	}
+	return squashed
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// printChange describes c and prints a unified diff against what is on disk
+func printChange(c change) error {
+	old := "/dev/null"
+	action := "create"
+	if c.Delete {
+		fmt.Printf("delete %s\n", c.Path)
+		return diff(c.Path, c.Path, "/dev/null")
+	}
+	if exists(c.Path) {
+		old = c.Path
+		action = "update"
+		if c.Generated {
+			action = "overwrite"
+			if !force {
+				action = "conflict"
+			}
+		}
+	}
+	fmt.Printf("%s %s\n", action, c.Path)
+
+	tmp, err := os.CreateTemp("", "generator-*"+filepath.Ext(c.Path))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(c.Contents); err != nil {
+		return err
+	}
+	tmp.Close()
+
+	return diff(c.Path, old, tmp.Name())
+}
+
+// diff prints a unified diff of two files, labelled as changes to path
+func diff(path, old, new string) error {
+	cmd := exec.Command("diff", "-u", "--label", "a/"+path, "--label", "b/"+path, old, new)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		// diff exits 1 when the files differ
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil
 		}
-		w.Flush()
-		f.Sync()
+		return fmt.Errorf("diff %s: %v", path, err)
 	}
+	return nil
+}
+
+func migrationID(now time.Time) string {
+	return fmt.Sprintf("%d%s%s%s%s", now.Year(), datePad(int(now.Month())), datePad(now.Day()), datePad(now.Hour()), datePad(now.Minute()))
 }
 
 func datePad(d int) string {
@@ -93,7 +484,419 @@ func datePad(d int) string {
 type myWriter struct {
 	Kind string
 	//KindLower         string
+	KindPlural        string
 	KindLowerPlural   string
 	KindLowerSingular string
+	KindTable         string
 	ID                string
+
+	// BasePath is the path of the REST API the kind is served under, e.g. /api/ocm-example-service/v1.
+	// It comes from pkg/identity so services renamed by `trex init` generate their own paths
+	BasePath string
+	// BasePathRef is BasePath escaped for a JSON pointer, as in the $refs of openapi.yaml
+	BasePathRef string
+	// ClientPrefix starts the names openapi-generator gives the client's methods, e.g. ApiOcmExampleServiceV1
+	ClientPrefix string
+	// ProtoPackage is the package of the kind's gRPC service, e.g. ocm_example.v1
+	ProtoPackage string
+
+	// Parent is the kind named by --belongs-to, empty when the kind has no parent
+	Parent              string
+	ParentPlural        string
+	ParentLowerPlural   string
+	ParentLowerSingular string
+	ParentTable         string
+	ParentColumn        string
+	HasMany             bool
+}
+
+func newWriter(kind, parent string, hasMany bool) (myWriter, error) {
+	// table and column names must match what gorm derives from the api structs
+	naming := schema.NamingStrategy{}
+
+	k := myWriter{
+		Kind:              kind,
+		KindPlural:        inflection.Plural(kind),
+		KindLowerPlural:   strings.ToLower(inflection.Plural(kind)),
+		KindLowerSingular: strings.ToLower(kind),
+		KindTable:         naming.TableName(kind),
+		BasePath:          identity.BasePath,
+		BasePathRef:       strings.ReplaceAll(identity.BasePath, "/", "~1"),
+		ClientPrefix:      clientPrefix(identity.BasePath),
+		ProtoPackage:      identity.ID + "." + identity.APIVersion,
+	}
+
+	if kind == "" || strings.ToUpper(kind[:1]) != kind[:1] {
+		return k, fmt.Errorf("--kind must be an exported Go name, e.g. Rocket, got %q", kind)
+	}
+
+	k.ID = migrationID(time.Now())
+
+	// regenerating a kind must not add a second migration, reuse the existing one's ID
+	existing, err := filepath.Glob(filepath.Join(outputRoot, "pkg/db/migrations", fmt.Sprintf("*_add_%s.go", k.KindLowerPlural)))
+	if err != nil {
+		return k, err
+	}
+	if len(existing) > 0 {
+		k.ID = strings.TrimSuffix(filepath.Base(existing[0]), fmt.Sprintf("_add_%s.go", k.KindLowerPlural))
+	}
+
+	if parent != "" {
+		k.Parent = parent
+		k.ParentPlural = inflection.Plural(parent)
+		k.ParentLowerPlural = strings.ToLower(inflection.Plural(parent))
+		k.ParentLowerSingular = strings.ToLower(parent)
+		k.ParentTable = naming.TableName(parent)
+		k.ParentColumn = naming.ColumnName("", parent+"ID")
+		k.HasMany = hasMany
+	}
+
+	return k, nil
+}
+
+var wordSeparators = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// clientPrefix turns /api/ocm-example-service/v1 into ApiOcmExampleServiceV1, as openapi-generator does
+func clientPrefix(path string) string {
+	var b strings.Builder
+	for _, word := range wordSeparators.Split(path, -1) {
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// formatSource gofmts generated go code so templates don't have to be whitespace-perfect
+func formatSource(path string, src []byte) ([]byte, error) {
+	if filepath.Ext(path) != ".go" {
+		return src, nil
+	}
+	formatted, err := format.Source(src)
+	if err != nil {
+		return nil, fmt.Errorf("generated invalid go in %s: %v", path, err)
+	}
+	return formatted, nil
+}
+
+// registration wires a generated kind into existing code. The rendered snippet is inserted
+// directly above the marker comment in Path, so the markers must stay in place.
+type registration struct {
+	Path     string
+	Marker   string
+	Template string
+}
+
+const (
+	migrationStructsPath = "pkg/db/migrations/migration_structs.go"
+	migrationsMarker     = "// +trex:scaffold:migrations"
+)
+
+var registrations = []registration{
+	{
+		Path:     "cmd/ocm-example-service/environments/types.go",
+		Marker:   "// +trex:scaffold:services",
+		Template: "{{.KindPlural}} {{.Kind}}ServiceLocator\n",
+	},
+	{
+		Path:     "cmd/ocm-example-service/environments/framework.go",
+		Marker:   "// +trex:scaffold:locators",
+		Template: "e.Services.{{.KindPlural}} = New{{.Kind}}ServiceLocator(e)\n",
+	},
+	{
+		Path:   "cmd/ocm-example-service/environments/service_types.go",
+		Marker: "// +trex:scaffold:service-locators",
+		Template: `type {{.Kind}}ServiceLocator func() services.{{.Kind}}Service
+
+func New{{.Kind}}ServiceLocator(env *Env) {{.Kind}}ServiceLocator {
+	return func() services.{{.Kind}}Service {
+		return services.New{{.Kind}}Service(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory, env.Clock),
+			&env.Database.SessionFactory,
+			dao.New{{.Kind}}Dao(&env.Database.SessionFactory),
+			env.Services.Events(),
+			env.Admission,
+			env.Hooks,
+			cache.For[api.{{.Kind}}](env.Caches, "{{.Kind}}"),
+		)
+	}
+}
+
+`,
+	},
+	{
+		Path:   "cmd/ocm-example-service/server/controllers.go",
+		Marker: "// +trex:scaffold:controllers",
+		Template: `{{.KindLowerSingular}}Services := e.Services.{{.KindPlural}}()
+
+s.KindControllerManager.Add(&controllers.ControllerConfig{
+	Source: "{{.KindPlural}}",
+	Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+		api.CreateEventType: {{"{"}}{{.KindLowerSingular}}Services.OnUpsert},
+		api.UpdateEventType: {{"{"}}{{.KindLowerSingular}}Services.OnUpsert},
+		api.DeleteEventType: {{"{"}}{{.KindLowerSingular}}Services.OnDelete},
+	},
+})
+
+`,
+	},
+	{
+		Path:     "pkg/db/advisory_locks.go",
+		Marker:   "// +trex:scaffold:locks",
+		Template: "{{.KindPlural}} LockType = \"{{.KindTable}}\"\n",
+	},
+	{
+		Path:     migrationStructsPath,
+		Marker:   migrationsMarker,
+		Template: "add{{.KindPlural}}(),\n",
+	},
+	{
+		Path:   "cmd/ocm-example-service/server/routes.go",
+		Marker: "// +trex:scaffold:routes",
+		Template: `//  {{.BasePath}}/{{.KindLowerPlural}}
+{{.KindLowerSingular}}Handler := handlers.New{{.Kind}}Handler(services.{{.KindPlural}}(), {{if .Parent}}services.{{.ParentPlural}}(), {{end}}services.Generic())
+apiV1{{.KindPlural}}Router := apiV1Router.PathPrefix("/{{.KindLowerPlural}}").Subrouter()
+apiV1{{.KindPlural}}Router.HandleFunc("", {{.KindLowerSingular}}Handler.List).Methods(http.MethodGet)
+apiV1{{.KindPlural}}Router.HandleFunc("/{id}", {{.KindLowerSingular}}Handler.Get).Methods(http.MethodGet)
+apiV1{{.KindPlural}}Router.HandleFunc("", {{.KindLowerSingular}}Handler.Create).Methods(http.MethodPost)
+apiV1{{.KindPlural}}Router.HandleFunc("/{id}", {{.KindLowerSingular}}Handler.Patch).Methods(http.MethodPatch)
+apiV1{{.KindPlural}}Router.HandleFunc("/{id}", {{.KindLowerSingular}}Handler.Delete).Methods(http.MethodDelete)
+apiV1{{.KindPlural}}Router.Use(authMiddleware.AuthenticateAccountJWT)
+apiV1{{.KindPlural}}Router.Use(authzMiddleware.AuthorizeApi)
+{{- if .Parent}}
+
+//  {{.BasePath}}/{{.ParentLowerPlural}}/{id}/{{.KindLowerPlural}}
+apiV1{{.ParentPlural}}Router.HandleFunc("/{id}/{{.KindLowerPlural}}", {{.KindLowerSingular}}Handler.ListBy{{.Parent}}).Methods(http.MethodGet)
+{{- end}}
+
+`,
+	},
+	{
+		Path:     "pkg/api/presenters/kind.go",
+		Marker:   "// +trex:scaffold:kinds",
+		Template: "case api.{{.Kind}}, *api.{{.Kind}}:\n\tresult = \"{{.Kind}}\"\n",
+	},
+	{
+		Path:     "pkg/api/presenters/path.go",
+		Marker:   "// +trex:scaffold:paths",
+		Template: "case api.{{.Kind}}, *api.{{.Kind}}:\n\treturn \"{{.KindLowerPlural}}\"\n",
+	},
+	{
+		Path:     "cmd/ocm-example-service/server/routes.go",
+		Marker:   "// +trex:scaffold:graphql",
+		Template: "graphql.NewKind(services.{{.KindPlural}}().FindByIDs),\n",
+	},
+	{
+		Path:     "cmd/ocm-example-service/server/grpc_server.go",
+		Marker:   "// +trex:scaffold:grpc",
+		Template: "pb.Register{{.KindPlural}}Server(s.grpcServer, rpc.New{{.Kind}}Server(e.Services.{{.KindPlural}}(), e.Services.Generic(), s.watches))\n",
+	},
+	{
+		Path:     "test/helper.go",
+		Marker:   "// +trex:scaffold:tables",
+		Template: "\"{{.KindTable}}\",\n",
+	},
+	{
+		Path:   "openapi/openapi.yaml",
+		Marker: "# +trex:scaffold:paths",
+		Template: `  {{.BasePath}}/{{.KindLowerPlural}}:
+    $ref: 'openapi.{{.KindLowerPlural}}.yaml#/paths/{{.BasePathRef}}~1{{.KindLowerPlural}}'
+  {{.BasePath}}/{{.KindLowerPlural}}/{id}:
+    $ref: 'openapi.{{.KindLowerPlural}}.yaml#/paths/{{.BasePathRef}}~1{{.KindLowerPlural}}~1{id}'
+{{- if .Parent}}
+  {{.BasePath}}/{{.ParentLowerPlural}}/{id}/{{.KindLowerPlural}}:
+    $ref: 'openapi.{{.KindLowerPlural}}.yaml#/paths/{{.BasePathRef}}~1{{.ParentLowerPlural}}~1{id}~1{{.KindLowerPlural}}'
+{{- end}}
+`,
+	},
+	{
+		Path:   "openapi/openapi.yaml",
+		Marker: "# +trex:scaffold:schemas",
+		Template: `    {{.Kind}}:
+      $ref: 'openapi.{{.KindLowerPlural}}.yaml#/components/schemas/{{.Kind}}'
+    {{.Kind}}List:
+      $ref: 'openapi.{{.KindLowerPlural}}.yaml#/components/schemas/{{.Kind}}List'
+    {{.Kind}}PatchRequest:
+      $ref: 'openapi.{{.KindLowerPlural}}.yaml#/components/schemas/{{.Kind}}PatchRequest'
+`,
+	},
+}
+
+// register renders r.Template and inserts it above r.Marker. Running the generator twice
+// for the same kind does not register it twice, in which case the returned change is nil.
+// pending holds contents already changed by earlier registrations but not yet written.
+func register(r registration, k myWriter, pending map[string][]byte) (*change, error) {
+	snippet, err := r.render(k)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(outputRoot, r.Path)
+	src, err := readPending(path, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := strings.Index(src, r.Marker)
+	if idx < 0 {
+		return nil, fmt.Errorf("marker %q not found in %s", r.Marker, path)
+	}
+	// insert at the start of the marker's line, indented like the marker
+	lineStart := strings.LastIndex(src[:idx], "\n") + 1
+	indent := src[lineStart:idx]
+
+	var indented strings.Builder
+	for _, line := range strings.SplitAfter(snippet, "\n") {
+		if strings.TrimSpace(line) != "" && filepath.Ext(r.Path) == ".go" {
+			indented.WriteString(indent)
+		}
+		indented.WriteString(line)
+	}
+	if strings.Contains(collapseSpace(src), collapseSpace(indented.String())) {
+		return nil, nil
+	}
+
+	src = src[:lineStart] + indented.String() + src[lineStart:]
+	formatted, err := formatSource(path, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return &change{Path: path, Contents: formatted}, nil
+}
+
+// unregister removes the snippet register inserted for k, however gofmt has realigned it since.
+// The returned change is nil if the kind was not registered in r.Path.
+func unregister(r registration, k myWriter, pending map[string][]byte) (*change, error) {
+	snippet, err := r.render(k)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(outputRoot, r.Path)
+	src, err := readPending(path, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	var quoted []string
+	for _, field := range strings.Fields(snippet) {
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	pattern := `(?m)^[ \t]*` + strings.Join(quoted, `\s+`) + `[ \t]*\n`
+	if strings.HasSuffix(snippet, "\n\n") {
+		pattern += `(?:[ \t]*\n)?`
+	}
+	loc := regexp.MustCompile(pattern).FindStringIndex(src)
+	if loc == nil {
+		return nil, nil
+	}
+
+	src = src[:loc[0]] + src[loc[1]:]
+	formatted, err := formatSource(path, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return &change{Path: path, Contents: formatted}, nil
+}
+
+func (r registration) render(k myWriter) (string, error) {
+	tmpl, err := template.New(r.Path).Parse(r.Template)
+	if err != nil {
+		return "", err
+	}
+	var snippet bytes.Buffer
+	if err := tmpl.Execute(&snippet, k); err != nil {
+		return "", err
+	}
+	return snippet.String(), nil
+}
+
+// readPending returns the contents of path, including changes planned but not yet written
+func readPending(path string, pending map[string][]byte) (string, error) {
+	if contents, ok := pending[path]; ok {
+		return string(contents), nil
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(contents), nil
+}
+
+// collapseSpace ignores gofmt alignment when comparing source
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// addHasMany adds the has-many side of the association to the parent's api struct,
+// e.g. Eggs []Egg on Dinosaur. gorm infers the foreign key from the child's ParentID field.
+func addHasMany(k myWriter) (*change, error) {
+	path, err := findStruct(k.Parent)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	field := fmt.Sprintf("\t%s []%s\n", k.KindPlural, k.Kind)
+
+	loc := structDecl(k.Parent).FindSubmatchIndex(contents)
+	if hasManyField(k).Match(contents[loc[2]:loc[3]]) {
+		return nil, nil
+	}
+	src := string(contents[:loc[3]]) + field + string(contents[loc[3]:])
+	formatted, err := formatSource(path, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return &change{Path: path, Contents: formatted}, nil
+}
+
+// removeHasMany undoes addHasMany
+func removeHasMany(k myWriter) (*change, error) {
+	path, err := findStruct(k.Parent)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := structDecl(k.Parent).FindSubmatchIndex(contents)
+	body := hasManyField(k).ReplaceAll(contents[loc[2]:loc[3]], nil)
+	src := string(contents[:loc[2]]) + string(body) + string(contents[loc[3]:])
+	formatted, err := formatSource(path, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	return &change{Path: path, Contents: formatted}, nil
+}
+
+func hasManyField(k myWriter) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`(?m)^\s*%s\s+\[\]%s[ \t]*\n`, k.KindPlural, k.Kind))
+}
+
+func structDecl(name string) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf(`(?m)^type %s struct {\n((?:.*\n)*?)}`, regexp.QuoteMeta(name)))
+}
+
+// findStruct returns the file in pkg/api that declares the api struct for a kind
+func findStruct(name string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(outputRoot, "pkg/api/*.go"))
+	if err != nil {
+		return "", err
+	}
+	for _, path := range files {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		if structDecl(name).Match(contents) {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("type %s struct not found in pkg/api", name)
 }
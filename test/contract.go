@@ -0,0 +1,151 @@
+package test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+const openAPIFile = "openapi/openapi.yaml"
+
+// Contract validates requests to the API and their responses against the OpenAPI specification, so the
+// presenters can't drift from the schema the generated clients are built from.
+type Contract struct {
+	router routers.Router
+}
+
+// NewContract loads the OpenAPI specification at path
+func NewContract(path string) (*Contract, error) {
+	doc, err := openapi3.NewLoader().LoadFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load OpenAPI specification %s: %s", path, err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("Invalid OpenAPI specification %s: %s", path, err)
+	}
+	// match the paths on any host, the test servers listen on ephemeral ports
+	doc.Servers = nil
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to route OpenAPI specification %s: %s", path, err)
+	}
+	return &Contract{router: router}, nil
+}
+
+// Validate checks a request and the response it got against the specification. Requests the server
+// rejected are allowed to violate the specification, tests send them on purpose, but their responses
+// must still conform. Requests to operations missing from the specification fail, they're served by no
+// documented route.
+func (c *Contract) Validate(r *http.Request, requestBody []byte, status int, header http.Header, responseBody []byte) error {
+	route, pathParams, err := c.router.FindRoute(r)
+	if err != nil {
+		return fmt.Errorf("%s %s isn't an operation of the OpenAPI specification: %s", r.Method, r.URL.Path, err)
+	}
+
+	ctx := context.Background()
+	request := r.Clone(ctx)
+	request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	requestInput := &openapi3filter.RequestValidationInput{
+		Request:    request,
+		PathParams: pathParams,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			SkipSettingDefaults: true,
+		},
+	}
+	if err := openapi3filter.ValidateRequest(ctx, requestInput); err != nil && status < http.StatusBadRequest {
+		return fmt.Errorf("%s %s was accepted with status %d, but violates the OpenAPI specification: %s", r.Method, r.URL.Path, status, err)
+	}
+
+	responseInput := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: requestInput,
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(responseBody)),
+		Options: &openapi3filter.Options{
+			IncludeResponseStatus: true,
+		},
+	}
+	if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+		return fmt.Errorf("The %d response to %s %s violates the OpenAPI specification: %s", status, r.Method, r.URL.Path, err)
+	}
+	return nil
+}
+
+// Middleware validates every request served by the API server, and its response, failing t on any
+// violation of the specification
+func (c *Contract) Middleware(t testing.TB) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var requestBody []byte
+			if r.Body != nil {
+				var err error
+				requestBody, err = io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("Unable to read request body of %s %s: %s", r.Method, r.URL.Path, err)
+				}
+				r.Body = io.NopCloser(bytes.NewReader(requestBody))
+			}
+			// validate the request as sent, before the server changes it
+			original := r.Clone(r.Context())
+
+			recorder := &contractRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			responseBody, err := decode(recorder.Header().Get("Content-Encoding"), recorder.body.Bytes())
+			if err != nil {
+				t.Errorf("Unable to decode response to %s %s: %s", r.Method, r.URL.Path, err)
+				return
+			}
+			header := recorder.Header().Clone()
+			header.Del("Content-Encoding")
+			if err := c.Validate(original, requestBody, recorder.status, header, responseBody); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// contractRecorder keeps a copy of the response written through it
+type contractRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *contractRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *contractRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// decode undoes the compression of the API's responses
+func decode(encoding string, body []byte) ([]byte, error) {
+	switch encoding {
+	case "gzip":
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(reader)
+	case "deflate":
+		return io.ReadAll(flate.NewReader(bytes.NewReader(body)))
+	default:
+		return body, nil
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+)
+
+const dinosaursPath = "/api/ocm-example-service/v1/dinosaurs"
+
+func newTestContract(t *testing.T) *Contract {
+	c, err := NewContract(filepath.Join(getProjectRootDir(), openAPIFile))
+	gm.Expect(err).NotTo(gm.HaveOccurred())
+	return c
+}
+
+func TestContractValidate(t *testing.T) {
+	gm.RegisterTestingT(t)
+	c := newTestContract(t)
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+
+	get := httptest.NewRequest(http.MethodGet, dinosaursPath+"/123", nil)
+	valid := `{"id":"123","kind":"Dinosaur","href":"` + dinosaursPath + `/123","species":"Triceratops"}`
+	gm.Expect(c.Validate(get, nil, http.StatusOK, jsonHeader, []byte(valid))).To(gm.Succeed())
+
+	// a presenter drifting from the schema
+	drifted := `{"id":"123","kind":"Dinosaur","species":42}`
+	gm.Expect(c.Validate(get, nil, http.StatusOK, jsonHeader, []byte(drifted))).To(gm.MatchError(gm.ContainSubstring("species")))
+
+	// a status the operation doesn't document
+	gm.Expect(c.Validate(get, nil, http.StatusTeapot, jsonHeader, []byte(`{}`))).NotTo(gm.Succeed())
+
+	// operations missing from the specification fail, whatever their response
+	errors := httptest.NewRequest(http.MethodGet, "/api/ocm-example-service/v1/errors", nil)
+	gm.Expect(c.Validate(errors, nil, http.StatusOK, jsonHeader, []byte(`[]`))).To(gm.MatchError(gm.ContainSubstring("isn't an operation")))
+	put := httptest.NewRequest(http.MethodPut, dinosaursPath+"/123", nil)
+	gm.Expect(c.Validate(put, nil, http.StatusNotFound, jsonHeader, []byte(`{}`))).To(gm.MatchError(gm.ContainSubstring("isn't an operation")))
+}
+
+func TestContractValidateRejectedRequest(t *testing.T) {
+	gm.RegisterTestingT(t)
+	c := newTestContract(t)
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+
+	junk := []byte(`{"species":["not","a","string"]}`)
+	post := httptest.NewRequest(http.MethodPost, dinosaursPath, nil)
+	post.Header.Set("Content-Type", "application/json")
+
+	// rejecting an invalid request is fine, as long as the error conforms
+	errorBody := `{"id":"17","kind":"Error","href":"/api/ocm-example-service/v1/errors/17","code":"OCM-EXAMPLE-17","reason":"bad"}`
+	gm.Expect(c.Validate(post, junk, http.StatusBadRequest, jsonHeader, []byte(errorBody))).To(gm.Succeed())
+
+	// accepting it is not
+	created := `{"id":"123","kind":"Dinosaur","href":"` + dinosaursPath + `/123"}`
+	gm.Expect(c.Validate(post, junk, http.StatusCreated, jsonHeader, []byte(created))).To(gm.MatchError(gm.ContainSubstring("was accepted")))
+}
+
+func TestContractMiddleware(t *testing.T) {
+	gm.RegisterTestingT(t)
+	c := newTestContract(t)
+
+	// the API compresses its responses, the recorded response is decompressed before validation
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"kind":"DinosaurList","page":1,"size":0,"total":0,"items":[]}`))
+		_ = gz.Close()
+	})
+
+	recorder := httptest.NewRecorder()
+	c.Middleware(t)(handler).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, dinosaursPath+"?page=1", strings.NewReader("")))
+	gm.Expect(recorder.Code).To(gm.Equal(http.StatusOK))
+	gm.Expect(recorder.Header().Get("Content-Encoding")).To(gm.Equal("gzip"))
+}
@@ -1,25 +1,17 @@
 package test
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/openshift-online/rh-trex/pkg/api"
 )
 
-func (helper *Helper) NewDinosaur(species string) *api.Dinosaur {
-	dinoService := helper.Env().Services.Dinosaurs()
-
-	dinosaur := &api.Dinosaur{
-		Species: species,
-	}
-
-	dino, err := dinoService.Create(context.Background(), dinosaur)
-	if err != nil {
-		helper.T.Errorf("error creating dinosaur: %q", err)
-	}
+func init() {
+	RegisterFactory(func(h *Helper) Creator[api.Dinosaur] { return h.Env().Services.Dinosaurs() })
+}
 
-	return dino
+func (helper *Helper) NewDinosaur(species string) *api.Dinosaur {
+	return Create(helper, func(dinosaur *api.Dinosaur) { dinosaur.Species = species })
 }
 
 func (helper *Helper) NewDinosaurList(namePrefix string, count int) (dinosaurs []*api.Dinosaur) {
@@ -0,0 +1,207 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/bxcodec/faker/v3"
+
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// Creator is the part of a kind's service the factories create objects with
+type Creator[T any] interface {
+	Create(ctx context.Context, obj *T) (*T, *errors.ServiceError)
+}
+
+// factories create the objects of the registered kinds, by the type of their api struct
+var factories = map[reflect.Type]func(h *Helper, obj interface{}) (interface{}, *errors.ServiceError){}
+
+// RegisterFactory lets Create make Ts through the service returned by service, so the objects created
+// emit their events like those created through the API do. Kinds register their factory in an init
+// function of their test/factories_<kind>.go.
+func RegisterFactory[T any](service func(h *Helper) Creator[T]) {
+	factories[typeOf[T]()] = func(h *Helper, obj interface{}) (interface{}, *errors.ServiceError) {
+		return service(h).Create(context.Background(), obj.(*T))
+	}
+}
+
+// Create makes a T with fake values in its fields, applies overrides to it in order, and creates it
+// through the kind's service, failing the test if it can't. IDs, timestamps and associations aren't faked.
+//
+// Create builds related objects too. A T belonging to another kind gets a parent created for it when
+// overrides leave its parent's ID empty, created from the parent struct if overrides set one, and a T
+// with has-many children set by overrides gets them created after itself:
+//
+//	egg := test.Create(h, func(e *api.Egg) { e.Dinosaur = &api.Dinosaur{Species: "Stegosaurus"} })
+//	dinosaur := test.Create(h, func(d *api.Dinosaur) { d.Eggs = make([]api.Egg, 3) })
+func Create[T any](h *Helper, overrides ...func(*T)) *T {
+	h.T.Helper()
+
+	obj := new(T)
+	if err := fake(reflect.ValueOf(obj).Elem()); err != nil {
+		h.T.Errorf("error faking %T: %s", obj, err)
+		return nil
+	}
+	for _, override := range overrides {
+		override(obj)
+	}
+
+	created, err := create(h, obj)
+	if err != nil {
+		h.T.Errorf("error creating %T: %s", obj, err)
+		return nil
+	}
+	return created.(*T)
+}
+
+// CreateList makes count Ts with Create, applying the same overrides to each
+func CreateList[T any](h *Helper, count int, overrides ...func(*T)) []*T {
+	h.T.Helper()
+
+	list := make([]*T, 0, count)
+	for i := 0; i < count; i++ {
+		list = append(list, Create(h, overrides...))
+	}
+	return list
+}
+
+// create creates obj, a pointer to the api struct of a registered kind, with its parent and children
+func create(h *Helper, obj interface{}) (interface{}, error) {
+	value := reflect.ValueOf(obj).Elem()
+	factory, found := factories[value.Type()]
+	if !found {
+		return nil, fmt.Errorf("no factory is registered for %s, register one with test.RegisterFactory", value.Type())
+	}
+
+	if err := createParents(h, value); err != nil {
+		return nil, err
+	}
+	created, serviceErr := factory(h, obj)
+	if serviceErr != nil {
+		return nil, serviceErr
+	}
+	if err := createChildren(h, reflect.ValueOf(created).Elem()); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// createParents creates the parents of the belongs-to associations of value whose foreign key is empty
+func createParents(h *Helper, value reflect.Value) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		parentType, ok := associated(field.Type)
+		if !ok || field.Type.Kind() != reflect.Ptr {
+			continue
+		}
+		key := value.FieldByName(field.Name + "ID")
+		if !key.IsValid() || key.Kind() != reflect.String || key.String() != "" {
+			continue
+		}
+
+		parent := value.Field(i)
+		if parent.IsNil() {
+			parent.Set(reflect.New(parentType))
+		}
+		if err := fake(parent.Elem()); err != nil {
+			return err
+		}
+		created, err := create(h, parent.Interface())
+		if err != nil {
+			return fmt.Errorf("error creating %s for it: %s", field.Name, err)
+		}
+		parent.Set(reflect.ValueOf(created))
+		key.SetString(idOf(parent.Elem()))
+	}
+	return nil
+}
+
+// createChildren creates the objects in the has-many associations of value, created already, with
+// their foreign key pointing at value
+func createChildren(h *Helper, value reflect.Value) error {
+	key := value.Type().Name() + "ID"
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		if _, ok := associated(field.Type.Elem()); !ok {
+			continue
+		}
+
+		children := value.Field(i)
+		for j := 0; j < children.Len(); j++ {
+			child := children.Index(j)
+			if child.Kind() == reflect.Ptr {
+				if child.IsNil() {
+					child.Set(reflect.New(child.Type().Elem()))
+				}
+				child = child.Elem()
+			}
+			parentKey := child.FieldByName(key)
+			if !parentKey.IsValid() || parentKey.Kind() != reflect.String {
+				return fmt.Errorf("%s of %s have no %s to belong to it by", field.Name, value.Type(), key)
+			}
+			parentKey.SetString(idOf(value))
+			if err := fake(child); err != nil {
+				return err
+			}
+			created, err := create(h, child.Addr().Interface())
+			if err != nil {
+				return fmt.Errorf("error creating %s for it: %s", field.Name, err)
+			}
+			child.Set(reflect.ValueOf(created).Elem())
+		}
+	}
+	return nil
+}
+
+// associated tells whether t is, or points to, the api struct of a registered kind
+func associated(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	_, found := factories[t]
+	return t, found
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func idOf(value reflect.Value) string {
+	return value.FieldByName("ID").String()
+}
+
+// fake fills the empty fields of value, an api struct, with faker. Only the kind's own scalar fields
+// are filled: embedded structs such as the Meta with the ID and timestamps, foreign keys and
+// associations are left alone.
+func fake(value reflect.Value) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() || field.Anonymous || strings.HasSuffix(field.Name, "ID") {
+			continue
+		}
+		switch field.Type.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			continue
+		}
+		if !value.Field(i).IsZero() {
+			continue
+		}
+
+		fake := reflect.New(field.Type)
+		if err := faker.FakeData(fake.Interface()); err != nil {
+			return fmt.Errorf("unable to fake %s.%s: %s", value.Type(), field.Name, err)
+		}
+		value.Field(i).Set(fake.Elem())
+	}
+	return nil
+}
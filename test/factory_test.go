@@ -0,0 +1,96 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex/pkg/api"
+	"github.com/openshift-online/rh-trex/pkg/errors"
+)
+
+// Nest and Fossil are kinds of the factory tests, a Fossil belongs to a Nest and a Nest has many fossils
+type Nest struct {
+	api.Meta
+	Site    string
+	Depth   int
+	Fossils []Fossil
+}
+
+type Fossil struct {
+	api.Meta
+	Bone   string
+	NestID string
+	Nest   *Nest
+}
+
+// memoryCreator creates objects by giving them an ID, as the services' DAOs do
+type memoryCreator[T any] struct {
+	created []*T
+	id      func(*T) *string
+}
+
+func (c *memoryCreator[T]) Create(ctx context.Context, obj *T) (*T, *errors.ServiceError) {
+	if obj == nil {
+		return nil, errors.Validation("nothing to create")
+	}
+	c.created = append(c.created, obj)
+	*c.id(obj) = fmt.Sprintf("%d", len(c.created))
+	return obj, nil
+}
+
+func TestCreate(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	nests := &memoryCreator[Nest]{id: func(n *Nest) *string { return &n.ID }}
+	fossils := &memoryCreator[Fossil]{id: func(f *Fossil) *string { return &f.ID }}
+	RegisterFactory(func(h *Helper) Creator[Nest] { return nests })
+	RegisterFactory(func(h *Helper) Creator[Fossil] { return fossils })
+	t.Cleanup(func() {
+		delete(factories, typeOf[Nest]())
+		delete(factories, typeOf[Fossil]())
+	})
+	h := &Helper{T: t}
+
+	// fields are faked, overrides win, and a parent is created for the Fossil
+	f := Create(h, func(f *Fossil) { f.Bone = "femur" })
+	gm.Expect(f.Bone).To(gm.Equal("femur"))
+	gm.Expect(f.ID).NotTo(gm.BeEmpty())
+	gm.Expect(f.CreatedAt.IsZero()).To(gm.BeTrue())
+	gm.Expect(nests.created).To(gm.HaveLen(1))
+	gm.Expect(f.NestID).To(gm.Equal(nests.created[0].ID))
+	gm.Expect(f.Nest.Site).NotTo(gm.BeEmpty())
+
+	// the parent may be shaped by the overrides, or given by ID
+	f = Create(h, func(f *Fossil) { f.Nest = &Nest{Site: "Hell Creek"} })
+	gm.Expect(f.Nest.Site).To(gm.Equal("Hell Creek"))
+	gm.Expect(f.NestID).To(gm.Equal(f.Nest.ID))
+	f = Create(h, func(f *Fossil) { f.NestID = nests.created[0].ID })
+	gm.Expect(f.Nest).To(gm.BeNil())
+	gm.Expect(nests.created).To(gm.HaveLen(2))
+
+	// children set by the overrides are created after their parent
+	n := Create(h, func(n *Nest) { n.Fossils = []Fossil{{Bone: "skull"}, {}} })
+	gm.Expect(nests.created).To(gm.HaveLen(3))
+	gm.Expect(fossils.created).To(gm.HaveLen(5))
+	gm.Expect(n.Fossils[0].Bone).To(gm.Equal("skull"))
+	gm.Expect(n.Fossils[1].Bone).NotTo(gm.BeEmpty())
+	for _, f := range n.Fossils {
+		gm.Expect(f.ID).NotTo(gm.BeEmpty())
+		gm.Expect(f.NestID).To(gm.Equal(n.ID))
+	}
+
+	gm.Expect(CreateList[Nest](h, 3)).To(gm.HaveLen(3))
+	gm.Expect(nests.created).To(gm.HaveLen(6))
+}
+
+func TestCreateUnregistered(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	r := &recordingT{TB: t}
+	gm.Expect(Create[Nest](&Helper{T: r})).To(gm.BeNil())
+	gm.Expect(r.failures).To(gm.HaveLen(1))
+	gm.Expect(r.failures[0]).To(gm.ContainSubstring("no factory is registered for test.Nest"))
+}
@@ -0,0 +1,162 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files under test/golden with the responses received")
+
+// goldenDir is where ExpectGolden keeps the golden files, relative to the project root
+var goldenDir = "test/golden"
+
+// generatedID matches the KSUIDs the service generates for its resources and operations
+var generatedID = regexp.MustCompile(`\b[0-9A-Za-z]{27}\b`)
+
+// ExpectGolden compares a JSON response body with the golden file test/golden/<name>.json, failing t on
+// a difference. The body is normalized first, see NormalizeJSON. Run the tests with -update to record
+// the golden files, and review their diff like any other change to the wire format.
+func ExpectGolden(t testing.TB, name string, body []byte) {
+	t.Helper()
+
+	normalized, err := NormalizeJSON(body)
+	if err != nil {
+		t.Errorf("Unable to normalize the response for golden file %s: %s\n%s", name, err, body)
+		return
+	}
+
+	path := filepath.Join(getProjectRootDir(), goldenDir, name+".json")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Unable to create golden file directory: %s", err)
+		}
+		if err := os.WriteFile(path, normalized, 0644); err != nil {
+			t.Fatalf("Unable to write golden file %s: %s", path, err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("Unable to read golden file %s, run the test with -update to record it: %s", path, err)
+		return
+	}
+	if !bytes.Equal(golden, normalized) {
+		t.Errorf("Response differs from golden file %s, run the test with -update if the change is intended:\n%s",
+			path, diffLines(string(golden), string(normalized)))
+	}
+}
+
+// NormalizeJSON indents body with sorted keys, and masks what changes from one run to the next:
+// timestamps become "<timestamp>", operation IDs "<operation-id>", and generated IDs "<id-N>",
+// numbered in order of appearance so a response referencing the same resource twice still shows it.
+func NormalizeJSON(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	ids := map[string]string{}
+	value = normalizeValue("", value, ids)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func normalizeValue(key string, value interface{}, ids map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// visit the keys in the order they are written, so IDs are numbered top to bottom
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			v[k] = normalizeValue(k, v[k], ids)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeValue(key, v[i], ids)
+		}
+		return v
+	case string:
+		return normalizeString(key, v, ids)
+	default:
+		return v
+	}
+}
+
+func normalizeString(key, s string, ids map[string]string) string {
+	if key == "operation_id" && s != "" {
+		return "<operation-id>"
+	}
+	if _, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return "<timestamp>"
+	}
+	return generatedID.ReplaceAllStringFunc(s, func(id string) string {
+		if _, found := ids[id]; !found {
+			ids[id] = fmt.Sprintf("<id-%d>", len(ids)+1)
+		}
+		return ids[id]
+	})
+}
+
+// diffLines shows the lines removed from want and added in got
+func diffLines(want, got string) string {
+	a := strings.Split(want, "\n")
+	b := strings.Split(got, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var diff strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			diff.WriteString("  " + a[i] + "\n")
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			diff.WriteString("+ " + b[j] + "\n")
+			j++
+		default:
+			diff.WriteString("- " + a[i] + "\n")
+			i++
+		}
+	}
+	return diff.String()
+}
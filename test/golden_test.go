@@ -0,0 +1,92 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gm "github.com/onsi/gomega"
+)
+
+// recordingT records the failures of ExpectGolden instead of failing the test
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// useGoldenDir points ExpectGolden at a directory of the test's own
+func useGoldenDir(t *testing.T) string {
+	dir, err := filepath.Rel(getProjectRootDir(), t.TempDir())
+	gm.Expect(err).NotTo(gm.HaveOccurred())
+	previous := goldenDir
+	goldenDir = dir
+	t.Cleanup(func() { goldenDir = previous })
+	return filepath.Join(getProjectRootDir(), dir)
+}
+
+func TestNormalizeJSON(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	body := `{"species":"Stegosaurus","id":"2NmyKVaMYbsLu2rcOJEbqTnyeqs","kind":"Dinosaur",` +
+		`"href":"/api/ocm-example-service/v1/dinosaurs/2NmyKVaMYbsLu2rcOJEbqTnyeqs",` +
+		`"created_at":"2023-03-07T15:04:05.999999Z","updated_at":"2023-03-07T10:04:05-05:00",` +
+		`"owner":"2NmyKZ1UEHPqCzQQ7uQbJdS0ppz","page":1,"operation_id":"2NmyKZ1UEHPqCzQQ7uQbJdS0ppz"}`
+
+	normalized, err := NormalizeJSON([]byte(body))
+	gm.Expect(err).NotTo(gm.HaveOccurred())
+	gm.Expect(string(normalized)).To(gm.Equal(`{
+  "created_at": "<timestamp>",
+  "href": "/api/ocm-example-service/v1/dinosaurs/<id-1>",
+  "id": "<id-1>",
+  "kind": "Dinosaur",
+  "operation_id": "<operation-id>",
+  "owner": "<id-2>",
+  "page": 1,
+  "species": "Stegosaurus",
+  "updated_at": "<timestamp>"
+}
+`))
+
+	_, err = NormalizeJSON([]byte(`{ this is invalid }`))
+	gm.Expect(err).To(gm.HaveOccurred())
+}
+
+func TestExpectGolden(t *testing.T) {
+	gm.RegisterTestingT(t)
+	dir := useGoldenDir(t)
+
+	// a missing golden file fails until it is recorded with -update
+	r := &recordingT{TB: t}
+	ExpectGolden(r, "dinosaur", []byte(`{"kind":"Dinosaur","species":"Stegosaurus"}`))
+	gm.Expect(r.failures).To(gm.HaveLen(1))
+
+	*updateGolden = true
+	ExpectGolden(t, "dinosaur", []byte(`{"kind":"Dinosaur","species":"Stegosaurus"}`))
+	*updateGolden = false
+	golden, err := os.ReadFile(filepath.Join(dir, "dinosaur.json"))
+	gm.Expect(err).NotTo(gm.HaveOccurred())
+	gm.Expect(string(golden)).To(gm.Equal("{\n  \"kind\": \"Dinosaur\",\n  \"species\": \"Stegosaurus\"\n}\n"))
+
+	// the order of the keys doesn't matter
+	r = &recordingT{TB: t}
+	ExpectGolden(r, "dinosaur", []byte(`{"species":"Stegosaurus","kind":"Dinosaur"}`))
+	gm.Expect(r.failures).To(gm.BeEmpty())
+
+	// a presenter dropping a field does
+	r = &recordingT{TB: t}
+	ExpectGolden(r, "dinosaur", []byte(`{"kind":"Dinosaur"}`))
+	gm.Expect(r.failures).To(gm.HaveLen(1))
+	gm.Expect(r.failures[0]).To(gm.ContainSubstring(`-   "species": "Stegosaurus"`))
+	gm.Expect(r.failures[0]).To(gm.ContainSubstring(`+   "kind": "Dinosaur"`))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/glog"
 	"github.com/google/uuid"
+	"github.com/gorilla/mux"
 	"github.com/segmentio/ksuid"
 	"github.com/spf13/pflag"
 
@@ -28,13 +29,15 @@ import (
 	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/server"
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/pkg/clock"
 	"github.com/openshift-online/rh-trex/pkg/config"
 	"github.com/openshift-online/rh-trex/pkg/db"
+	"github.com/openshift-online/rh-trex/pkg/db/db_session"
+	"github.com/openshift-online/rh-trex/pkg/identity"
 	"github.com/openshift-online/rh-trex/test/mocks"
 )
 
 const (
-	apiPort    = ":8777"
 	jwtKeyFile = "test/support/jwt_private_key.pem"
 	jwtCAFile  = "test/support/jwt_ca.pem"
 	jwkKID     = "uhctestkey"
@@ -47,10 +50,8 @@ var once sync.Once
 // TODO jwk mock server needs to be refactored out of the helper and into the testing environment
 var jwkURL string
 
-// TimeFunc defines a way to get a new Time instance common to the entire test suite.
-// Aria's environment has Virtual Time that may not be actual time. We compensate
-// by synchronizing on a common time func attached to the test harness.
-type TimeFunc func() time.Time
+// contract validates the traffic of the isolated helpers' API servers against the OpenAPI specification
+var contract *Contract
 
 type Helper struct {
 	Ctx               context.Context
@@ -59,14 +60,76 @@ type Helper struct {
 	APIServer         server.Server
 	MetricsServer     server.Server
 	HealthCheckServer server.Server
-	TimeFunc          TimeFunc
+	Clock             *clock.Fake
 	JWTPrivateKey     *rsa.PrivateKey
 	JWTCA             *rsa.PublicKey
-	T                 *testing.T
+	T                 testing.TB
 	teardowns         []func() error
+	env               *environments.Env
+	apiMiddleware     []mux.MiddlewareFunc
 }
 
+// NewHelper returns the process-wide helper, initializing the testing environment and starting the
+// shared servers the first time it is called
 func NewHelper(t *testing.T) *Helper {
+	sharedHelper()
+	helper.T = t
+	return helper
+}
+
+// NewIsolatedHelper returns a helper with a database of its own, cloned from template1, and an API
+// server of its own listening on an ephemeral port. Both are removed when the test finishes, so tests
+// using it may call t.Parallel(). The environment's clients, the JWT keys and the metrics and health
+// check servers are shared with NewHelper.
+func NewIsolatedHelper(t *testing.T) *Helper {
+	return newIsolatedHelper(t, contract.Middleware(t))
+}
+
+// NewBenchmarkHelper returns an isolated helper whose API server doesn't validate its traffic against
+// the OpenAPI specification, the validation would dominate the latencies measured
+func NewBenchmarkHelper(b *testing.B) *Helper {
+	return newIsolatedHelper(b)
+}
+
+func newIsolatedHelper(t testing.TB, apiMiddleware ...mux.MiddlewareFunc) *Helper {
+	shared := sharedHelper()
+
+	dbFactory, err := db_session.NewIsolatedTestFactory(shared.AppConfig.Database)
+	if err != nil {
+		t.Fatalf("Unable to create the test database: %s", err)
+	}
+	env := shared.Env().Isolated(dbFactory)
+	env.Config.Server.BindAddress = "localhost:0"
+	fakeClock := clock.NewFake(time.Now())
+	env.SetClock(fakeClock)
+
+	h := &Helper{
+		Ctx:               shared.Ctx,
+		DBFactory:         dbFactory,
+		AppConfig:         env.Config,
+		MetricsServer:     shared.MetricsServer,
+		HealthCheckServer: shared.HealthCheckServer,
+		Clock:             fakeClock,
+		JWTPrivateKey:     shared.JWTPrivateKey,
+		JWTCA:             shared.JWTCA,
+		T:                 t,
+		env:               env,
+		apiMiddleware:     apiMiddleware,
+	}
+	h.startAPIServer()
+
+	t.Cleanup(func() {
+		if err := h.stopAPIServer(); err != nil {
+			t.Errorf("error stopping api server: %s", err)
+		}
+		if err := dbFactory.Drop(); err != nil {
+			t.Errorf("error dropping test database: %s", err)
+		}
+	})
+	return h
+}
+
+func sharedHelper() *Helper {
 	once.Do(func() {
 		jwtKey, jwtCA, err := parseJWTKeys()
 		if err != nil {
@@ -86,14 +149,25 @@ func NewHelper(t *testing.T) *Helper {
 		}
 		pflag.Parse()
 
+		// the services and database sessions take the time from the helper's clock, which only moves
+		// when a test advances it
+		fakeClock := clock.NewFake(time.Now())
+		env.Clock = fakeClock
+
 		err = env.Initialize()
 		if err != nil {
 			glog.Fatalf("Unable to initialize testing environment: %s", err.Error())
 		}
 
+		contract, err = NewContract(filepath.Join(getProjectRootDir(), openAPIFile))
+		if err != nil {
+			glog.Fatalf("Unable to load API contract: %s", err.Error())
+		}
+
 		helper = &Helper{
 			AppConfig:     environments.Environment().Config,
 			DBFactory:     environments.Environment().Database.SessionFactory,
+			Clock:         fakeClock,
 			JWTPrivateKey: jwtKey,
 			JWTCA:         jwtCA,
 		}
@@ -109,11 +183,13 @@ func NewHelper(t *testing.T) *Helper {
 		helper.startMetricsServer()
 		helper.startHealthCheckServer()
 	})
-	helper.T = t
 	return helper
 }
 
 func (helper *Helper) Env() *environments.Env {
+	if helper.env != nil {
+		return helper.env
+	}
 	return environments.Environment()
 }
 
@@ -129,11 +205,13 @@ func (helper *Helper) Teardown() {
 func (helper *Helper) startAPIServer() {
 	// TODO jwk mock server needs to be refactored out of the helper and into the testing environment
 	helper.Env().Config.Server.JwkCertURL = jwkURL
-	helper.APIServer = server.NewAPIServer()
+	helper.APIServer = server.NewAPIServerForEnv(helper.Env(), helper.apiMiddleware...)
 	listener, err := helper.APIServer.Listen()
 	if err != nil {
 		glog.Fatalf("Unable to start Test API server: %s", err)
 	}
+	// when bound to port 0, record the port picked, so restarts and clients use the same one
+	helper.Env().Config.Server.BindAddress = listener.Addr().String()
 	go func() {
 		glog.V(10).Info("Test API server started")
 		helper.APIServer.Serve(listener)
@@ -217,11 +295,16 @@ func (helper *Helper) NewUUID() string {
 }
 
 func (helper *Helper) RestURL(path string) string {
+	return fmt.Sprintf("%s%s%s", helper.serverURL(), identity.BasePath, path)
+}
+
+// serverURL is the root of this helper's API server
+func (helper *Helper) serverURL() string {
 	protocol := "http"
 	if helper.AppConfig.Server.EnableHTTPS {
 		protocol = "https"
 	}
-	return fmt.Sprintf("%s://%s/api/ocm-example-service/v1%s", protocol, helper.AppConfig.Server.BindAddress, path)
+	return fmt.Sprintf("%s://%s", protocol, helper.AppConfig.Server.BindAddress)
 }
 
 func (helper *Helper) MetricsURL(path string) string {
@@ -234,6 +317,7 @@ func (helper *Helper) HealthCheckURL(path string) string {
 
 func (helper *Helper) NewApiClient() *openapi.APIClient {
 	config := openapi.NewConfiguration()
+	config.Servers = openapi.ServerConfigurations{{URL: helper.serverURL()}}
 	client := openapi.NewAPIClient(config)
 	return client
 }
@@ -320,6 +404,8 @@ func (helper *Helper) MigrateDBTo(migrationID string) {
 
 func (helper *Helper) ClearAllTables() {
 	helper.DeleteAll(&api.Dinosaur{})
+	helper.DeleteAll(&api.CustomResource{})
+	helper.DeleteAll(&api.CustomKind{})
 }
 
 func (helper *Helper) CleanDB() error {
@@ -327,8 +413,12 @@ func (helper *Helper) CleanDB() error {
 
 	// TODO: this list should not be static or otherwise not hard-coded here.
 	for _, table := range []string{
+		// +trex:scaffold:tables
 		"dinosaurs",
 		"events",
+		"outbox_offsets",
+		"custom_resources",
+		"custom_kinds",
 		"migrations",
 	} {
 		if g2.Migrator().HasTable(table) {
@@ -0,0 +1,45 @@
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/test"
+)
+
+func TestCustomKindNonAdmin(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	// the test server has no --admin-users, so no account is an admin
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	// 403 defining a custom kind
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"name": "feature_flag", "schema": {"type": "object"}}`).
+		Post(h.RestURL("/custom_kinds"))
+	Expect(err).NotTo(HaveOccurred(), "Error posting object:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+
+	// 403 deleting one, before it is even looked up
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/custom_kinds/feature_flag"))
+	Expect(err).NotTo(HaveOccurred(), "Error deleting object:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusForbidden))
+
+	// everyone can still read them
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Get(h.RestURL("/custom_kinds"))
+	Expect(err).NotTo(HaveOccurred(), "Error listing objects:  %v", err)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+}
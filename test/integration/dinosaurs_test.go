@@ -10,6 +10,7 @@ import (
 
 	"github.com/openshift-online/rh-trex/pkg/api"
 	"github.com/openshift-online/rh-trex/pkg/dao"
+	"github.com/openshift-online/rh-trex/pkg/errors"
 	"github.com/openshift-online/rh-trex/pkg/services"
 
 	. "github.com/onsi/gomega"
@@ -80,6 +81,30 @@ func TestDinosaurPost(t *testing.T) {
 	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
 }
 
+func TestDinosaurPostAbortedByHook(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	// the hooks are those of the test's own environment
+	services.AfterCreate(h.Env().Hooks, func(ctx context.Context, dinosaur *api.Dinosaur) *errors.ServiceError {
+		return errors.Conflict("%s is taken", dinosaur.Species)
+	})
+
+	dino := openapi.Dinosaur{
+		Species: openapi.PtrString("Stegosaurus"),
+	}
+	_, resp, err := client.DefaultApi.ApiOcmExampleServiceV1DinosaursPost(ctx).Dinosaur(dino).Execute()
+	Expect(err).To(HaveOccurred(), "Expected 409")
+	Expect(resp.StatusCode).To(Equal(http.StatusConflict))
+	Expect(*h.OpenapiError(err).Reason).To(Equal("Stegosaurus is taken"))
+
+	// the dinosaur was created before the hook ran, and rolled back with its event
+	Expect(h.Count("dinosaurs")).To(Equal(int64(0)))
+	Expect(h.Count("events")).To(Equal(int64(0)))
+}
+
 func TestDinosaurPatch(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -140,7 +165,9 @@ func contains(et api.EventType, events api.EventList) bool {
 }
 
 func TestDinosaurPaging(t *testing.T) {
+	t.Parallel()
 	h, client := test.RegisterIntegration(t)
+	g := NewWithT(t)
 
 	account := h.NewRandAccount()
 	ctx := h.NewAuthenticatedContext(account)
@@ -149,22 +176,24 @@ func TestDinosaurPaging(t *testing.T) {
 	_ = h.NewDinosaurList("Bronto", 20)
 
 	list, _, err := client.DefaultApi.ApiOcmExampleServiceV1DinosaursGet(ctx).Execute()
-	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
-	Expect(len(list.Items)).To(Equal(20))
-	Expect(list.Size).To(Equal(int32(20)))
-	Expect(list.Total).To(Equal(int32(20)))
-	Expect(list.Page).To(Equal(int32(1)))
+	g.Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	g.Expect(len(list.Items)).To(Equal(20))
+	g.Expect(list.Size).To(Equal(int32(20)))
+	g.Expect(list.Total).To(Equal(int32(20)))
+	g.Expect(list.Page).To(Equal(int32(1)))
 
 	list, _, err = client.DefaultApi.ApiOcmExampleServiceV1DinosaursGet(ctx).Page(2).Size(5).Execute()
-	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
-	Expect(len(list.Items)).To(Equal(5))
-	Expect(list.Size).To(Equal(int32(5)))
-	Expect(list.Total).To(Equal(int32(20)))
-	Expect(list.Page).To(Equal(int32(2)))
+	g.Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	g.Expect(len(list.Items)).To(Equal(5))
+	g.Expect(list.Size).To(Equal(int32(5)))
+	g.Expect(list.Total).To(Equal(int32(20)))
+	g.Expect(list.Page).To(Equal(int32(2)))
 }
 
 func TestDinosaurListSearch(t *testing.T) {
+	t.Parallel()
 	h, client := test.RegisterIntegration(t)
+	g := NewWithT(t)
 
 	account := h.NewRandAccount()
 	ctx := h.NewAuthenticatedContext(account)
@@ -173,10 +202,36 @@ func TestDinosaurListSearch(t *testing.T) {
 
 	search := fmt.Sprintf("id in ('%s')", dinosaurs[0].ID)
 	list, _, err := client.DefaultApi.ApiOcmExampleServiceV1DinosaursGet(ctx).Search(search).Execute()
-	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
-	Expect(len(list.Items)).To(Equal(1))
-	Expect(list.Total).To(Equal(int32(20)))
-	Expect(*list.Items[0].Id).To(Equal(dinosaurs[0].ID))
+	g.Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	g.Expect(len(list.Items)).To(Equal(1))
+	g.Expect(list.Total).To(Equal(int32(20)))
+	g.Expect(*list.Items[0].Id).To(Equal(dinosaurs[0].ID))
+}
+
+func TestDinosaurFactory(t *testing.T) {
+	t.Parallel()
+	h, client := test.RegisterIntegration(t)
+	g := NewWithT(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	// the factory fakes the species, and creates the dinosaurs through the service so their events fire
+	dinosaurs := test.CreateList[api.Dinosaur](h, 3)
+	g.Expect(dinosaurs).To(HaveLen(3))
+	g.Expect(dinosaurs[0].Species).NotTo(BeEmpty())
+	g.Expect(dinosaurs[0].Species).NotTo(Equal(dinosaurs[1].Species))
+
+	events, err := h.Env().Services.Events().All(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+	for _, dinosaur := range dinosaurs {
+		g.Expect(events).To(ContainElement(WithTransform(func(e *api.Event) string { return e.SourceID }, Equal(dinosaur.ID))))
+	}
+
+	dinosaur := test.Create(h, func(d *api.Dinosaur) { d.Species = "Ankylosaurus" })
+	found, _, getErr := client.DefaultApi.ApiOcmExampleServiceV1DinosaursIdGet(ctx, dinosaur.ID).Execute()
+	g.Expect(getErr).NotTo(HaveOccurred())
+	g.Expect(*found.Species).To(Equal("Ankylosaurus"))
 }
 
 func TestUpdateDinosaurWithRacingRequests(t *testing.T) {
@@ -0,0 +1,58 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-online/rh-trex/test"
+)
+
+// TestDinosaurGolden pins the wire format of the dinosaur endpoints to the files under test/golden. When a
+// change to the format is intended, record the new files with
+//
+//	make test-integration TESTFLAGS="-run TestDinosaurGolden -update"
+func TestDinosaurGolden(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	request := func() *resty.Request {
+		return resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", h.CreateJWTString(account)))
+	}
+
+	resp, err := request().SetBody(`{"species": "Stegosaurus"}`).Post(h.RestURL("/dinosaurs"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusCreated))
+	test.ExpectGolden(t, "dinosaurs_post", resp.Body())
+
+	var created struct {
+		ID string `json:"id"`
+	}
+	Expect(json.Unmarshal(resp.Body(), &created)).To(Succeed())
+
+	resp, err = request().Get(h.RestURL("/dinosaurs/" + created.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+	test.ExpectGolden(t, "dinosaurs_get", resp.Body())
+
+	resp, err = request().SetBody(`{"species": "Triceratops"}`).Patch(h.RestURL("/dinosaurs/" + created.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+	test.ExpectGolden(t, "dinosaurs_patch", resp.Body())
+
+	resp, err = request().SetQueryParam("search", "species = 'Triceratops'").Get(h.RestURL("/dinosaurs"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusOK))
+	test.ExpectGolden(t, "dinosaurs_list", resp.Body())
+
+	resp, err = request().Get(h.RestURL("/dinosaurs/nonexistent"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode()).To(Equal(http.StatusNotFound))
+	test.ExpectGolden(t, "dinosaurs_get_not_found", resp.Body())
+}
@@ -0,0 +1,108 @@
+package test
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Latencies records how long operations take, by operation name, so load tests and benchmarks can
+// report percentiles rather than averages. It is safe for concurrent use.
+type Latencies struct {
+	mu      sync.Mutex
+	samples map[string][]time.Duration
+	errors  map[string]int
+}
+
+// LatencyReport summarizes the latencies of one operation, in the form written to benchmark reports
+type LatencyReport struct {
+	Benchmark string  `json:"benchmark"`
+	Operation string  `json:"operation"`
+	Count     int     `json:"count"`
+	Errors    int     `json:"errors"`
+	PerSecond float64 `json:"per_second"`
+	P50Ms     float64 `json:"p50_ms"`
+	P90Ms     float64 `json:"p90_ms"`
+	P95Ms     float64 `json:"p95_ms"`
+	P99Ms     float64 `json:"p99_ms"`
+	MaxMs     float64 `json:"max_ms"`
+}
+
+func NewLatencies() *Latencies {
+	return &Latencies{
+		samples: map[string][]time.Duration{},
+		errors:  map[string]int{},
+	}
+}
+
+// Time runs fn and records its latency under operation, counting an error if it returns one
+func (l *Latencies) Time(operation string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	l.Record(operation, time.Since(start), err)
+	return err
+}
+
+func (l *Latencies) Record(operation string, latency time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.samples[operation] = append(l.samples[operation], latency)
+	if err != nil {
+		l.errors[operation]++
+	}
+}
+
+// Report summarizes every operation recorded, sorted by name. The throughput is computed over elapsed,
+// the wall time the operations were driven for.
+func (l *Latencies) Report(benchmark string, elapsed time.Duration) []LatencyReport {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	operations := make([]string, 0, len(l.samples))
+	for operation := range l.samples {
+		operations = append(operations, operation)
+	}
+	sort.Strings(operations)
+
+	reports := []LatencyReport{}
+	for _, operation := range operations {
+		samples := append([]time.Duration{}, l.samples[operation]...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		report := LatencyReport{
+			Benchmark: benchmark,
+			Operation: operation,
+			Count:     len(samples),
+			Errors:    l.errors[operation],
+			P50Ms:     milliseconds(Percentile(samples, 50)),
+			P90Ms:     milliseconds(Percentile(samples, 90)),
+			P95Ms:     milliseconds(Percentile(samples, 95)),
+			P99Ms:     milliseconds(Percentile(samples, 99)),
+			MaxMs:     milliseconds(samples[len(samples)-1]),
+		}
+		if elapsed > 0 {
+			report.PerSecond = float64(len(samples)) / elapsed.Seconds()
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// Percentile returns the nearest-rank pth percentile of sorted, which must be in ascending order
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	gm "github.com/onsi/gomega"
+)
+
+func TestPercentile(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	samples := []time.Duration{}
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	gm.Expect(Percentile(samples, 50)).To(gm.Equal(50 * time.Millisecond))
+	gm.Expect(Percentile(samples, 99)).To(gm.Equal(99 * time.Millisecond))
+	gm.Expect(Percentile(samples, 100)).To(gm.Equal(100 * time.Millisecond))
+	gm.Expect(Percentile(samples, 0)).To(gm.Equal(1 * time.Millisecond))
+
+	gm.Expect(Percentile([]time.Duration{7}, 99)).To(gm.Equal(time.Duration(7)))
+	gm.Expect(Percentile(nil, 50)).To(gm.Equal(time.Duration(0)))
+}
+
+func TestLatenciesReport(t *testing.T) {
+	gm.RegisterTestingT(t)
+
+	latencies := NewLatencies()
+	for i := 10; i >= 1; i-- {
+		latencies.Record("get", time.Duration(i)*time.Millisecond, nil)
+	}
+	err := latencies.Time("create", func() error { return fmt.Errorf("conflict") })
+	gm.Expect(err).To(gm.HaveOccurred())
+
+	reports := latencies.Report("BenchmarkDinosaurs", 2*time.Second)
+	gm.Expect(reports).To(gm.HaveLen(2))
+
+	gm.Expect(reports[0].Operation).To(gm.Equal("create"))
+	gm.Expect(reports[0].Count).To(gm.Equal(1))
+	gm.Expect(reports[0].Errors).To(gm.Equal(1))
+
+	get := reports[1]
+	gm.Expect(get.Benchmark).To(gm.Equal("BenchmarkDinosaurs"))
+	gm.Expect(get.Operation).To(gm.Equal("get"))
+	gm.Expect(get.Count).To(gm.Equal(10))
+	gm.Expect(get.Errors).To(gm.Equal(0))
+	gm.Expect(get.PerSecond).To(gm.Equal(5.0))
+	gm.Expect(get.P50Ms).To(gm.Equal(5.0))
+	gm.Expect(get.P90Ms).To(gm.Equal(9.0))
+	gm.Expect(get.P99Ms).To(gm.Equal(10.0))
+	gm.Expect(get.MaxMs).To(gm.Equal(10.0))
+}
@@ -0,0 +1,55 @@
+package performance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openshift-online/rh-trex/cmd/ocm-example-service/server"
+	"github.com/openshift-online/rh-trex/test"
+)
+
+// BenchmarkControllerEvents measures how many dinosaur events the controllers reconcile per second,
+// handling them over -concurrency workers as competing controller replicas would
+func BenchmarkControllerEvents(b *testing.B) {
+	h := test.NewBenchmarkHelper(b)
+	controllers := server.NewControllersServerForEnv(h.Env())
+	events := h.Env().Services.Events()
+
+	b.Run("concurrent", func(b *testing.B) {
+		b.StopTimer()
+		// every dinosaur created emits an event, those of the previous runs are reconciled already
+		h.NewDinosaurList("events", b.N)
+		all, err := events.All(context.Background())
+		if err != nil {
+			b.Fatalf("Unable to list events: %s", err)
+		}
+		pending := []string{}
+		for _, event := range all {
+			if event.ReconciledDate == nil {
+				pending = append(pending, event.ID)
+			}
+		}
+
+		latencies := test.NewLatencies()
+		b.StartTimer()
+		elapsed := drive(len(pending), func(i int) {
+			_ = latencies.Time("event", func() error {
+				controllers.KindControllerManager.Handle(pending[i])
+				return nil
+			})
+		})
+		b.StopTimer()
+
+		unreconciled := 0
+		for _, id := range pending {
+			event, err := events.Get(context.Background(), id)
+			if err != nil || event.ReconciledDate == nil {
+				unreconciled++
+			}
+		}
+		if unreconciled > 0 {
+			b.Errorf("%d of %d events weren't reconciled", unreconciled, len(pending))
+		}
+		report(b, latencies, elapsed)
+	})
+}
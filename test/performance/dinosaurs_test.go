@@ -0,0 +1,177 @@
+package performance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gopkg.in/resty.v1"
+
+	"github.com/openshift-online/rh-trex/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex/test"
+)
+
+// dinosaurClient drives the dinosaurs API as one account
+type dinosaurClient struct {
+	h      *test.Helper
+	client *openapi.APIClient
+	ctx    context.Context
+	token  string
+}
+
+func newDinosaurClient(b *testing.B) *dinosaurClient {
+	h := test.NewBenchmarkHelper(b)
+	client := h.NewApiClient()
+	// keep a connection per worker, rather than reconnecting on every request
+	client.GetConfig().HTTPClient = &http.Client{
+		Transport: &http.Transport{MaxIdleConnsPerHost: *concurrencyFlag},
+	}
+	account := h.NewRandAccount()
+	return &dinosaurClient{
+		h:      h,
+		client: client,
+		ctx:    h.NewAuthenticatedContext(account),
+		token:  h.CreateJWTString(account),
+	}
+}
+
+func (c *dinosaurClient) create(species string) (string, error) {
+	dino, _, err := c.client.DefaultApi.ApiOcmExampleServiceV1DinosaursPost(c.ctx).
+		Dinosaur(openapi.Dinosaur{Species: openapi.PtrString(species)}).
+		Execute()
+	if err != nil {
+		return "", err
+	}
+	return *dino.Id, nil
+}
+
+func (c *dinosaurClient) get(id string) error {
+	_, _, err := c.client.DefaultApi.ApiOcmExampleServiceV1DinosaursIdGet(c.ctx, id).Execute()
+	return err
+}
+
+func (c *dinosaurClient) patch(id, species string) error {
+	_, _, err := c.client.DefaultApi.ApiOcmExampleServiceV1DinosaursIdPatch(c.ctx, id).
+		DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: openapi.PtrString(species)}).
+		Execute()
+	return err
+}
+
+// delete goes around the generated client, which doesn't have the operation
+func (c *dinosaurClient) delete(id string) error {
+	resp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", c.token)).
+		Delete(c.h.RestURL("/dinosaurs/" + id))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return fmt.Errorf("unexpected status %d deleting dinosaur %s", resp.StatusCode(), id)
+	}
+	return nil
+}
+
+func (c *dinosaurClient) list(search string) error {
+	request := c.client.DefaultApi.ApiOcmExampleServiceV1DinosaursGet(c.ctx).Page(1).Size(100)
+	if search != "" {
+		request = request.Search(search)
+	}
+	_, _, err := request.Execute()
+	return err
+}
+
+// seed grows the dinosaurs table to rows, through the test factories
+func (c *dinosaurClient) seed(seeded, rows int) int {
+	if rows > seeded {
+		c.h.NewDinosaurList(fmt.Sprintf("rows%d", rows), rows-seeded)
+	}
+	return rows
+}
+
+// BenchmarkDinosaurCRUD creates, reads, updates and deletes a dinosaur per operation
+func BenchmarkDinosaurCRUD(b *testing.B) {
+	c := newDinosaurClient(b)
+
+	b.Run("concurrent", func(b *testing.B) {
+		latencies := test.NewLatencies()
+		b.ResetTimer()
+		elapsed := drive(b.N, func(i int) {
+			var id string
+			err := latencies.Time("create", func() (err error) {
+				id, err = c.create(fmt.Sprintf("crud_%d", i))
+				return err
+			})
+			if err != nil {
+				return
+			}
+			_ = latencies.Time("get", func() error { return c.get(id) })
+			_ = latencies.Time("patch", func() error { return c.patch(id, fmt.Sprintf("crud_%d_patched", i)) })
+			_ = latencies.Time("delete", func() error { return c.delete(id) })
+		})
+		b.StopTimer()
+		report(b, latencies, elapsed)
+	})
+}
+
+// BenchmarkDinosaurList measures how list and search latencies scale with the size of the table
+func BenchmarkDinosaurList(b *testing.B) {
+	c := newDinosaurClient(b)
+
+	seeded := 0
+	for _, rows := range rows(b) {
+		seeded = c.seed(seeded, rows)
+		search := fmt.Sprintf("species like 'rows%d_1%%'", rows)
+
+		b.Run(fmt.Sprintf("rows=%d", rows), func(b *testing.B) {
+			latencies := test.NewLatencies()
+			b.ResetTimer()
+			elapsed := drive(b.N, func(i int) {
+				if i%2 == 0 {
+					_ = latencies.Time("list", func() error { return c.list("") })
+				} else {
+					_ = latencies.Time("search", func() error { return c.list(search) })
+				}
+			})
+			b.StopTimer()
+			report(b, latencies, elapsed)
+		})
+	}
+}
+
+// BenchmarkDinosaurMix drives a read heavy mix of operations against the smallest table size
+func BenchmarkDinosaurMix(b *testing.B) {
+	c := newDinosaurClient(b)
+	sizes := rows(b)
+	seeded := c.seed(0, sizes[0])
+	ids := []string{}
+	for _, dino := range c.h.NewDinosaurList("mix", *concurrencyFlag) {
+		ids = append(ids, dino.ID)
+	}
+
+	b.Run(fmt.Sprintf("rows=%d", seeded), func(b *testing.B) {
+		latencies := test.NewLatencies()
+		b.ResetTimer()
+		elapsed := drive(b.N, func(i int) {
+			id := ids[i%len(ids)]
+			// out of every 10 operations: 1 create, 5 gets, 1 patch, 2 lists and 1 search
+			switch i % 10 {
+			case 0:
+				_ = latencies.Time("create", func() error {
+					_, err := c.create(fmt.Sprintf("mix_%d", i))
+					return err
+				})
+			case 1, 2, 3, 4, 5:
+				_ = latencies.Time("get", func() error { return c.get(id) })
+			case 6:
+				_ = latencies.Time("patch", func() error { return c.patch(id, fmt.Sprintf("mix_%d_patched", i)) })
+			case 7, 8:
+				_ = latencies.Time("list", func() error { return c.list("") })
+			case 9:
+				_ = latencies.Time("search", func() error { return c.list("species like 'mix%'") })
+			}
+		})
+		b.StopTimer()
+		report(b, latencies, elapsed)
+	})
+}
@@ -0,0 +1,119 @@
+package performance
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex/test"
+)
+
+var (
+	rowsFlag        = flag.String("rows", "100,1000,10000", "comma separated table sizes to measure list and search latencies at")
+	concurrencyFlag = flag.Int("concurrency", 8, "number of concurrent clients driving the API and controllers")
+	reportFlag      = flag.String("report", "", "file to write the latency percentiles of every benchmark to, as JSON")
+)
+
+var (
+	resultsMu sync.Mutex
+	results   = map[string][]test.LatencyReport{}
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	glog.Infof("Starting performance tests using go version %s", runtime.Version())
+	helper := test.NewHelper(&testing.T{})
+	exitCode := m.Run()
+	helper.Teardown()
+	if err := writeReport(*reportFlag); err != nil {
+		glog.Errorf("Unable to write the benchmark report: %s", err)
+		exitCode = 1
+	}
+	os.Exit(exitCode)
+}
+
+// rows parses the table sizes to benchmark at
+func rows(b *testing.B) []int {
+	sizes := []int{}
+	for _, size := range strings.Split(*rowsFlag, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil || n < 0 {
+			b.Fatalf("Invalid table size %q in -rows", size)
+		}
+		sizes = append(sizes, n)
+	}
+	sort.Ints(sizes)
+	return sizes
+}
+
+// drive runs the n operations of a benchmark over the -concurrency workers, and returns the wall time
+// they took
+func drive(n int, op func(i int)) time.Duration {
+	var next int64 = -1
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < *concurrencyFlag; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(atomic.AddInt64(&next, 1)); i < n; i = int(atomic.AddInt64(&next, 1)) {
+				op(i)
+			}
+		}()
+	}
+	wg.Wait()
+	return time.Since(start)
+}
+
+// report adds the latency percentiles and throughput of every operation to the benchmark's results, and
+// keeps them for the -report file. The benchmark runs with growing b.N, the last run's results are kept.
+func report(b *testing.B, latencies *test.Latencies, elapsed time.Duration) {
+	reports := latencies.Report(b.Name(), elapsed)
+	for _, r := range reports {
+		b.ReportMetric(r.P50Ms, r.Operation+"-p50-ms")
+		b.ReportMetric(r.P95Ms, r.Operation+"-p95-ms")
+		b.ReportMetric(r.P99Ms, r.Operation+"-p99-ms")
+		b.ReportMetric(r.PerSecond, r.Operation+"/s")
+		if r.Errors > 0 {
+			b.Errorf("%d of %d %s operations failed", r.Errors, r.Count, r.Operation)
+		}
+	}
+
+	resultsMu.Lock()
+	defer resultsMu.Unlock()
+	results[b.Name()] = reports
+}
+
+func writeReport(path string) error {
+	if path == "" {
+		return nil
+	}
+	resultsMu.Lock()
+	defer resultsMu.Unlock()
+
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	all := []test.LatencyReport{}
+	for _, name := range names {
+		all = append(all, results[name]...)
+	}
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -9,14 +9,14 @@ import (
 )
 
 // Register a test
-// This should be run before every integration test
+// This should be run before every integration test. Each test gets a database and API server of its own,
+// see NewIsolatedHelper, so tests may call t.Parallel(). Parallel tests must make their assertions with
+// gm.NewWithT(t): the package level Expect reports failures to the test registered last.
 func RegisterIntegration(t *testing.T) (*Helper, *openapi.APIClient) {
 	// Register the test with gomega
 	gm.RegisterTestingT(t)
-	// Create a new helper
-	helper := NewHelper(t)
-	// Reset the database to a seeded blank state
-	helper.DBFactory.ResetDB()
+	// Create a new helper, with a blank database cloned from the migrated template
+	helper := NewIsolatedHelper(t)
 	// Create an api client
 	client := helper.NewApiClient()
 